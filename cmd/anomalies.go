@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var anomaliesCmd = &cobra.Command{
+	Use:   "anomalies <series-id>",
+	Short: "Detect anomalous consumption in a measurement series",
+	Long: `Flag intervals that are unusual both compared to the preceding window
+(rolling z-score) and to earlier values at the same hour of the week.
+
+Lower --sensitivity values flag more intervals. History for the hour-of-week
+baseline is fetched before --from, which defaults to the last day.
+
+The command exits with code 2 if any anomaly is found, which makes it
+suitable for cron jobs and monitoring checks.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		var opts eon.AnomalyOptions
+		opts.Threshold, _ = cmd.Flags().GetFloat64("sensitivity")
+		opts.Window, _ = cmd.Flags().GetDuration("window")
+		opts.SeasonalWeeks, _ = cmd.Flags().GetInt("weeks")
+		opts.HighOnly, _ = cmd.Flags().GetBool("high-only")
+		opts.Location, err = time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		to := time.Now()
+		if t := dateFlag(cmd, "to"); t != nil {
+			to = *t
+		}
+		from := to.AddDate(0, 0, -1)
+		if t := dateFlag(cmd, "from"); t != nil {
+			from = *t
+		}
+
+		report, err := eon.GetAnomalies(clientInstance, seriesID, eon.Resolution(resolution), from, to, opts)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printAnomalies(cmd, report, opts.Location)
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+
+		if len(report.Anomalies) > 0 {
+			os.Exit(2)
+		}
+	},
+}
+
+func init() {
+	anomaliesCmd.Flags().String("from", "", "Start date (YYYY-MM-DD), one day before --to if unset")
+	anomaliesCmd.Flags().String("to", "", "End date (YYYY-MM-DD), now if unset")
+	anomaliesCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour, day")
+	anomaliesCmd.Flags().Float64("sensitivity", 3, "Absolute z-score at which an interval is flagged")
+	anomaliesCmd.Flags().Duration("window", 7*24*time.Hour, "Rolling window preceding each interval")
+	anomaliesCmd.Flags().Int("weeks", 8, "Weeks of history for the hour-of-week baseline")
+	anomaliesCmd.Flags().Bool("high-only", false, "Only flag consumption above the baseline")
+	anomaliesCmd.Flags().String("timezone", "Local", "Time zone for the hour-of-week baseline (e.g. Europe/Stockholm)")
+	anomaliesCmd.Flags().String("output", "table", "Output format: table, json")
+
+	rootCmd.AddCommand(anomaliesCmd)
+}
+
+// printAnomalies prints the flagged intervals with their baselines and scores
+func printAnomalies(cmd *cobra.Command, report eon.AnomalyReport, loc *time.Location) {
+	score := func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%+.1f", *v)
+	}
+
+	var rows [][]string
+	for _, a := range report.Anomalies {
+		expected := "-"
+		if a.Expected != nil {
+			expected = fmt.Sprintf("%.2f", *a.Expected)
+		}
+		rows = append(rows, []string{
+			a.Time.In(loc).Format("2006-01-02 15:04"),
+			fmt.Sprintf("%.2f", a.Value),
+			fmt.Sprintf("%.2f", a.RollingMean),
+			expected,
+			score(a.RollingScore),
+			score(a.SeasonalScore),
+			a.Direction,
+		})
+	}
+	printTable(cmd, []string{"TIME", "VALUE", "ROLLING MEAN", "EXPECTED", "ROLLING Z", "SEASONAL Z", "DIRECTION"}, rows)
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%d of %d intervals flagged at sensitivity %.1f\n", len(report.Anomalies), report.Checked, report.Threshold)
+}
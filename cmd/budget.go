@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Track monthly costs against budgets",
+	Long: `Project month-end consumption and cost of installations and compare them
+with the monthly budgets of the config file.
+
+Example config (eon.json):
+  {
+    "budgets": [
+      {"installation": "735999163005019944", "monthly": 20000},
+      {"installation": "735999163005019945", "monthly": 5000, "includeVAT": true}
+    ]
+  }
+
+Month-to-date consumption is extrapolated over the month and priced with the
+average unit price of the preceding months.`,
+}
+
+var budgetForecastCmd = &cobra.Command{
+	Use:   "forecast [installation-id...]",
+	Short: "Forecast month-end cost of installations with a budget",
+	Run: func(cmd *cobra.Command, args []string) {
+		forecasts := budgetForecasts(cmd, args)
+
+		output, _ := cmd.Flags().GetString("output")
+		switch output {
+		case "table":
+			printForecasts(cmd, forecasts)
+		case "json":
+			gout.MustPrint(forecasts)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+var budgetCheckCmd = &cobra.Command{
+	Use:   "check [installation-id...]",
+	Short: "Check whether installations are projected to exceed their budget",
+	Long: `Print the installations projected to exceed their monthly budget.
+
+The command exits with code 2 if any installation is projected over budget,
+which makes it suitable for cron jobs and monitoring checks.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var over []eon.MonthForecast
+		for _, f := range budgetForecasts(cmd, args) {
+			if f.OverBudget {
+				over = append(over, f)
+			}
+		}
+
+		if len(over) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "All installations are projected within budget")
+			return
+		}
+		printForecasts(cmd, over)
+		os.Exit(2)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{budgetForecastCmd, budgetCheckCmd} {
+		c.Flags().String("config", "eon.json", "Path to the config file")
+		c.Flags().Int("history", 3, "Months of costs used for the unit price")
+		c.Flags().String("timezone", "Local", "Time zone of the month (e.g. Europe/Stockholm)")
+		budgetCmd.AddCommand(c)
+	}
+	budgetForecastCmd.Flags().String("output", "table", "Output format: table, json")
+
+	rootCmd.AddCommand(budgetCmd)
+}
+
+// budgetForecasts forecasts the configured installations, or only those given as args
+func budgetForecasts(cmd *cobra.Command, args []string) []eon.MonthForecast {
+	cfg, err := loadConfig(cmd)
+	cobra.CheckErr(err)
+
+	history, _ := cmd.Flags().GetInt("history")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	loc, err := time.LoadLocation(timezone)
+	cobra.CheckErr(err)
+
+	budgets := map[string]budgetConfig{}
+	for _, b := range cfg.Budgets {
+		budgets[b.Installation] = b
+	}
+
+	selected := cfg.Budgets
+	if len(args) > 0 {
+		selected = nil
+		for _, id := range args {
+			b, ok := budgets[id]
+			if !ok {
+				cobra.CheckErr(fmt.Errorf("no budget configured for installation %s", id))
+			}
+			selected = append(selected, b)
+		}
+	}
+
+	now := time.Now()
+	forecasts := []eon.MonthForecast{}
+	for _, b := range selected {
+		forecast, err := eon.GetForecast(clientInstance, b.Installation, now, eon.ForecastOptions{
+			SeriesID:      b.SeriesID,
+			HistoryMonths: history,
+			Budget:        b.Monthly,
+			IncludeVAT:    b.IncludeVAT,
+			Location:      loc,
+		})
+		cobra.CheckErr(err)
+		forecasts = append(forecasts, forecast)
+	}
+	return forecasts
+}
+
+// printForecasts prints month-to-date and projected cost against the budget of each installation
+func printForecasts(cmd *cobra.Command, forecasts []eon.MonthForecast) {
+	var rows [][]string
+	for _, f := range forecasts {
+		usage, status := "-", "ok"
+		if f.BudgetUsage != nil {
+			usage = fmt.Sprintf("%.1f%%", *f.BudgetUsage*100)
+		}
+		if f.OverBudget {
+			status = "OVER"
+		}
+		budget := "-"
+		if f.Budget != nil {
+			budget = formatAmount(*f.Budget)
+		}
+
+		rows = append(rows, []string{
+			f.Installation,
+			f.Month.Format("2006-01"),
+			fmt.Sprintf("%.0f%%", f.Elapsed*100),
+			fmt.Sprintf("%.1f %s", f.Consumption, f.Unit),
+			fmt.Sprintf("%.1f %s", f.ProjectedConsumption, f.Unit),
+			formatAmount(f.Cost),
+			formatAmount(f.ProjectedCost),
+			formatAmount(f.ProjectedCostInclVAT),
+			budget,
+			usage,
+			status,
+		})
+	}
+	printTable(cmd, []string{
+		"INSTALLATION", "MONTH", "ELAPSED", "CONSUMPTION", "PROJECTED",
+		"COST", "PROJECTED COST", "INCL VAT", "BUDGET", "USAGE", "STATUS",
+	}, rows)
+}
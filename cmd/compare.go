@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <series-id>",
+	Short: "Compare a measurement series between two periods",
+	Long: `Compare a period of a measurement series with another period, bucket by bucket.
+
+Periods are given as YYYY, YYYY-MM or YYYY-MM-DD. If --against is omitted the
+same period one year earlier is used.
+
+Alignment options:
+  - calendar: match the same day of month and time of day
+  - weekday: shift the reference period so both start on the same weekday`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		periodFlag, _ := cmd.Flags().GetString("period")
+		againstFlag, _ := cmd.Flags().GetString("against")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		alignment, _ := cmd.Flags().GetString("align")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		period, err := eon.ParsePeriod(periodFlag, loc)
+		cobra.CheckErr(err)
+
+		against := eon.Interval{From: period.From.AddDate(-1, 0, 0), To: period.To.AddDate(-1, 0, 0)}
+		if againstFlag != "" {
+			against, err = eon.ParsePeriod(againstFlag, loc)
+			cobra.CheckErr(err)
+		}
+
+		comparison, err := eon.GetComparison(clientInstance, seriesID, eon.Resolution(resolution), period, against, eon.Alignment(alignment), loc)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printComparison(cmd, comparison, loc)
+		case "json":
+			gout.MustPrint(comparison)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+func init() {
+	compareCmd.Flags().String("period", "", "Period to compare (YYYY, YYYY-MM or YYYY-MM-DD)")
+	compareCmd.Flags().String("against", "", "Reference period (defaults to one year before --period)")
+	compareCmd.Flags().String("resolution", "day", "Resolution: quarter, hour, day, month")
+	compareCmd.Flags().String("align", "calendar", "Alignment: calendar, weekday")
+	compareCmd.Flags().String("timezone", "Local", "Time zone of the periods (e.g. Europe/Stockholm)")
+	compareCmd.Flags().String("output", "table", "Output format: table, json")
+	_ = compareCmd.MarkFlagRequired("period")
+
+	rootCmd.AddCommand(compareCmd)
+}
+
+// printComparison prints one row per aligned bucket followed by the totals
+func printComparison(cmd *cobra.Command, comparison eon.Comparison, loc *time.Location) {
+	timestamp := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.In(loc).Format("2006-01-02 15:04")
+	}
+	value := func(v *float64, format string) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf(format, *v)
+	}
+
+	var rows [][]string
+	for _, b := range comparison.Buckets {
+		rows = append(rows, []string{
+			timestamp(b.Time),
+			value(b.Value, "%.3f"),
+			timestamp(b.AgainstTime),
+			value(b.Against, "%.3f"),
+			value(b.Delta, "%+.3f"),
+			value(b.DeltaPercent, "%+.1f%%"),
+		})
+	}
+	rows = append(rows, []string{
+		"TOTAL",
+		fmt.Sprintf("%.3f", comparison.Total),
+		"",
+		fmt.Sprintf("%.3f", comparison.AgainstTotal),
+		fmt.Sprintf("%+.3f", comparison.Delta),
+		value(comparison.DeltaPercent, "%+.1f%%"),
+	})
+	printTable(cmd, []string{"TIME", "VALUE", "AGAINST TIME", "AGAINST", "DELTA", "DELTA %"}, rows)
+}
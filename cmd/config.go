@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+// config is the configuration file read by the watch, budget and emissions commands.
+// Environment variables such as ${SMTP_PASSWORD} are expanded in webhook URLs and headers
+// and in SMTP passwords.
+type config struct {
+	Watch           watchConfig               `json:"watch"`
+	Budgets         []budgetConfig            `json:"budgets"`
+	EmissionFactors eon.StaticEmissionFactors `json:"emissionFactors"` // kgCO2e per kWh by carrier and price area
+}
+
+// budgetConfig is the monthly budget of an installation
+type budgetConfig struct {
+	Installation string  `json:"installation"`
+	Monthly      float64 `json:"monthly"`
+	IncludeVAT   bool    `json:"includeVAT"` // Budget includes VAT
+	SeriesID     int     `json:"seriesId"`   // Consumption series, detected if zero
+}
+
+// watchConfig holds the rules and notifiers of the watch command
+type watchConfig struct {
+	Interval  eon.Duration          `json:"interval"`  // Time between checks, 15m if zero
+	StateFile string                `json:"stateFile"` // Sent alerts, eon-state.json if empty
+	Rules     []eon.Rule            `json:"rules"`
+	Webhooks  []eon.WebhookNotifier `json:"webhooks"`
+	Emails    []eon.EmailNotifier   `json:"emails"`
+	Commands  []eon.CommandNotifier `json:"commands"`
+}
+
+// loadConfig reads the file given by the --config flag
+func loadConfig(cmd *cobra.Command) (config, error) {
+	path, _ := cmd.Flags().GetString("config")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config{}, err
+	}
+
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return config{}, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	cfg.Watch.expandEnv()
+	return cfg, nil
+}
+
+// expandEnv expands environment variables in the notifier fields meant to hold secrets.
+// Other values are kept as written, including any $.
+func (c *watchConfig) expandEnv() {
+	for i := range c.Webhooks {
+		c.Webhooks[i].URL = os.ExpandEnv(c.Webhooks[i].URL)
+		for name, value := range c.Webhooks[i].Headers {
+			c.Webhooks[i].Headers[name] = os.ExpandEnv(value)
+		}
+	}
+	for i := range c.Emails {
+		c.Emails[i].Password = os.ExpandEnv(c.Emails[i].Password)
+	}
+}
+
+// notifiers returns every configured notifier
+func (c watchConfig) notifiers() []eon.Notifier {
+	var notifiers []eon.Notifier
+	for _, n := range c.Webhooks {
+		notifiers = append(notifiers, n)
+	}
+	for _, n := range c.Emails {
+		notifiers = append(notifiers, n)
+	}
+	for _, n := range c.Commands {
+		notifiers = append(notifiers, n)
+	}
+	return notifiers
+}
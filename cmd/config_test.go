@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("SMTP_PASSWORD", `p"ss\word`)
+	t.Setenv("HOOK_TOKEN", "secret")
+
+	path := filepath.Join(t.TempDir(), "eon.json")
+	err := os.WriteFile(path, []byte(`{
+		"watch": {
+			"webhooks": [{"url": "https://hooks.example.com/eon", "headers": {"Authorization": "Bearer ${HOOK_TOKEN}"}}],
+			"emails": [{"addr": "smtp.example.com:587", "password": "${SMTP_PASSWORD}"}],
+			"commands": [{"command": "notify", "args": ["cost over $100"]}]
+		}
+	}`), 0o600)
+	assert.NoError(t, err)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", path, "")
+
+	cfg, err := loadConfig(cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret", cfg.Watch.Webhooks[0].Headers["Authorization"])
+	assert.Equal(t, `p"ss\word`, cfg.Watch.Emails[0].Password)
+	assert.Equal(t, []string{"cost over $100"}, cfg.Watch.Commands[0].Args)
+}
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
 	"github.com/spf13/cobra"
 )
 
@@ -11,13 +13,27 @@ var costsCmd = &cobra.Command{
 	Use:   "costs <installation-id>",
 	Short: "Get cost data for an installation",
 	Long: `Retrieve cost information for a specific installation.
-Whole months are considered for the time range.`,
+Whole months are considered for the time range.
+
+Output formats:
+  - json: JSON document (default)
+  - parquet: Parquet files below --dir with grid and biogas details expanded
+    into columns, partitioned by installation and month
+  - arrow: Arrow IPC files below --dir with the same columns and partitions
+
+Use --summary to print a breakdown table of every cost component per month,
+quarter or year with grand totals. Totals add up the top-level components;
+grid and biogas details are listed as a breakdown. Combine with --output json
+for the summary as JSON.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		installationID := args[0]
 
 		fromFlag, _ := cmd.Flags().GetString("from")
 		toFlag, _ := cmd.Flags().GetString("to")
+		output, _ := cmd.Flags().GetString("output")
+		summary, _ := cmd.Flags().GetBool("summary")
+		period, _ := cmd.Flags().GetString("period")
 
 		var from, to *time.Time
 		var err error
@@ -36,13 +52,87 @@ Whole months are considered for the time range.`,
 		costs, err := clientInstance.GetCosts(installationID, from, to)
 		cobra.CheckErr(err)
 
-		gout.MustPrint(costs)
+		if summary {
+			result, err := eon.SummarizeCosts(costs, eon.SummaryPeriod(period))
+			cobra.CheckErr(err)
+
+			if cmd.Flags().Changed("output") && output == "json" {
+				gout.MustPrint(result)
+			} else {
+				printCostSummary(cmd, result)
+			}
+			return
+		}
+
+		switch output {
+		case "json":
+			gout.MustPrint(costs)
+		case "parquet", "arrow":
+			rows, err := eon.CostRows(costs)
+			cobra.CheckErr(err)
+
+			dir, _ := cmd.Flags().GetString("dir")
+			write := eon.WriteCostsParquet
+			if output == "arrow" {
+				write = eon.WriteCostsArrow
+			}
+			paths, err := write(dir, rows)
+			cobra.CheckErr(err)
+			printPaths(cmd, paths)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
 	},
 }
 
 func init() {
 	costsCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
 	costsCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	costsCmd.Flags().String("output", "json", "Output format: json, parquet, arrow")
+	costsCmd.Flags().String("dir", ".", "Output directory for parquet and arrow files")
+	costsCmd.Flags().Bool("summary", false, "Print a cost breakdown table with totals")
+	costsCmd.Flags().String("period", "month", "Summary period: month, quarter, year")
 
 	rootCmd.AddCommand(costsCmd)
 }
+
+// printCostSummary prints totals per period followed by a breakdown of the grand totals
+func printCostSummary(cmd *cobra.Command, summary eon.CostSummary) {
+	headers := []string{"PERIOD"}
+	for _, c := range summary.Total.Components {
+		if !c.Detail {
+			headers = append(headers, c.Component)
+		}
+	}
+	headers = append(headers, "TOTAL", "TOTAL INCL VAT")
+
+	row := func(p eon.CostPeriodSummary) []string {
+		cells := []string{p.Period}
+		for _, c := range p.Components {
+			if !c.Detail {
+				cells = append(cells, formatAmount(c.ExclVAT))
+			}
+		}
+		return append(cells, formatAmount(p.TotalExclVAT), formatAmount(p.TotalInclVAT))
+	}
+
+	var rows [][]string
+	for _, p := range summary.Periods {
+		rows = append(rows, row(p))
+	}
+	rows = append(rows, row(summary.Total))
+	printTable(cmd, headers, rows)
+
+	fmt.Fprintln(cmd.OutOrStdout())
+
+	rows = nil
+	for _, c := range summary.Total.Components {
+		name := c.Component
+		if c.Detail {
+			name = "  " + name
+		}
+		rows = append(rows, []string{name, formatAmount(c.ExclVAT), formatAmount(c.InclVAT)})
+	}
+	rows = append(rows, []string{"TOTAL", formatAmount(summary.Total.TotalExclVAT), formatAmount(summary.Total.TotalInclVAT)})
+	printTable(cmd, []string{"COMPONENT", "EXCL VAT", "INCL VAT"}, rows)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var emissionsCmd = &cobra.Command{
+	Use:   "emissions [installation-id...]",
+	Short: "Estimate carbon emissions of installations",
+	Long: `Estimate kgCO2e of the consumption of installations (all if none are given),
+split by energy carrier (electricity, heat, cold, gas) based on each
+installation's energy class.
+
+Static factors in kgCO2e per kWh are read from the emissionFactors of the
+config file, per carrier and price area ("*" for any area):
+
+  {
+    "emissionFactors": {
+      "electricity": {"SE1": 0.02, "SE4": 0.06, "*": 0.04},
+      "heat": {"*": 0.05},
+      "cold": {"*": 0.01},
+      "gas": {"*": 0.2}
+    }
+  }
+
+An hourly electricity factor file (e.g. grid carbon intensity) can be given
+with --hourly in the price file formats, with a factor column in place of
+price. Static factors apply to other carriers and hours the file lacks.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		hourly, _ := cmd.Flags().GetString("hourly")
+		grams, _ := cmd.Flags().GetBool("grams")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		var factors eon.EmissionFactorSource = eon.StaticEmissionFactors{}
+		if cfg, err := loadConfig(cmd); err == nil {
+			factors = cfg.EmissionFactors
+		} else if hourly == "" || cmd.Flags().Changed("config") {
+			cobra.CheckErr(err)
+		}
+		if hourly != "" {
+			factors, err = eon.LoadEmissionFactorFile(hourly, eon.EmissionFileOptions{Location: loc, Grams: grams, Fallback: factors})
+			cobra.CheckErr(err)
+		}
+
+		from, err := requiredDateFlag(cmd, "from")
+		cobra.CheckErr(err)
+		to, err := requiredDateFlag(cmd, "to")
+		cobra.CheckErr(err)
+
+		summary, err := eon.GetEmissions(clientInstance, args, eon.Resolution(resolution), from, to, factors)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printTable(cmd, emissionHeaders, emissionRows(summary))
+			fmt.Fprintln(cmd.OutOrStdout())
+
+			var rows [][]string
+			for _, c := range append(summary.Carriers, summary.Total) {
+				rows = append(rows, []string{string(c.Carrier), fmt.Sprint(c.Installations), fmt.Sprintf("%.1f", c.Consumption), fmt.Sprintf("%.1f", c.Emissions)})
+			}
+			printTable(cmd, []string{"CARRIER", "INSTALLATIONS", "CONSUMPTION (kWh)", "EMISSIONS (kgCO2e)"}, rows)
+
+			for _, e := range summary.Errors {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", e)
+			}
+		case "csv":
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write(emissionHeaders))
+			cobra.CheckErr(w.WriteAll(emissionRows(summary)))
+		case "json":
+			gout.MustPrint(summary)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+var emissionHeaders = []string{"INSTALLATION", "CARRIER", "PRICE AREA", "CONSUMPTION (kWh)", "EMISSIONS (kgCO2e)", "kgCO2e/kWh"}
+
+// emissionRows returns one row per installation
+func emissionRows(summary eon.EmissionSummary) [][]string {
+	var rows [][]string
+	for _, r := range summary.Installations {
+		rows = append(rows, []string{
+			r.Installation,
+			string(r.Carrier),
+			r.PriceArea,
+			fmt.Sprintf("%.1f", r.Consumption),
+			fmt.Sprintf("%.1f", r.Emissions),
+			fmt.Sprintf("%.4f", r.AverageFactor),
+		})
+	}
+	return rows
+}
+
+func init() {
+	emissionsCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	emissionsCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	emissionsCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour, day, month (hourly factors need quarter or hour)")
+	emissionsCmd.Flags().String("config", "eon.json", "Path to the config file with static emission factors")
+	emissionsCmd.Flags().String("hourly", "", "Hourly electricity emission factor file (.csv or .json)")
+	emissionsCmd.Flags().Bool("grams", false, "Factors in the hourly file are gCO2e per kWh")
+	emissionsCmd.Flags().String("timezone", "Local", "Time zone of timestamps without offset in the hourly file")
+	emissionsCmd.Flags().String("output", "table", "Output format: table, csv, json")
+	_ = emissionsCmd.MarkFlagRequired("from")
+	_ = emissionsCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(emissionsCmd)
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var heatCmd = &cobra.Command{
+	Use:   "heat <installation-id>",
+	Short: "Analyse costs of district heating or cooling",
+	Long: `Relate the effect, energy and flow costs of a district heating or cooling
+installation to its measured energy and flow per month.
+
+With a flow series (m³) the average delta-T between supply and return water
+is reported. With a daily outdoor temperature file (--temperatures, CSV with
+date and temperature columns) consumption is normalised per heating degree-day
+for heat and per cooling degree-day for cold.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		energySeries, _ := cmd.Flags().GetInt("energy-series")
+		flowSeries, _ := cmd.Flags().GetInt("flow-series")
+		temperaturesFile, _ := cmd.Flags().GetString("temperatures")
+		heatingBase, _ := cmd.Flags().GetFloat64("heating-base")
+		coolingBase, _ := cmd.Flags().GetFloat64("cooling-base")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		opts := eon.HeatOptions{
+			EnergySeriesID: energySeries,
+			FlowSeriesID:   flowSeries,
+			DegreeDays:     eon.DegreeDayOptions{HeatingBase: heatingBase, CoolingBase: coolingBase},
+			Location:       loc,
+		}
+		if temperaturesFile != "" {
+			opts.Temperatures, err = eon.LoadTemperatureFile(temperaturesFile, loc)
+			cobra.CheckErr(err)
+		}
+
+		from, err := requiredDateFlag(cmd, "from")
+		cobra.CheckErr(err)
+		to, err := requiredDateFlag(cmd, "to")
+		cobra.CheckErr(err)
+
+		report, err := eon.GetHeatAnalysis(clientInstance, args[0], from, to, opts)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printTable(cmd, heatHeaders, heatRows(report))
+		case "csv":
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write(heatHeaders))
+			cobra.CheckErr(w.WriteAll(heatRows(report)))
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+var heatHeaders = []string{"MONTH", "ENERGY (kWh)", "FLOW (m3)", "DELTA-T (K)", "DEGREE-DAYS", "kWh/DEGREE-DAY", "EFFECT", "ENERGY COST", "FLOW COST", "TOTAL", "COST/kWh"}
+
+// heatRows returns one row per month followed by the total
+func heatRows(report eon.HeatReport) [][]string {
+	optional := func(v *float64, format string) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf(format, *v)
+	}
+	component := func(m eon.HeatMonth, name string) string {
+		if v, ok := m.Component(name); ok {
+			return formatAmount(v)
+		}
+		return "-"
+	}
+
+	var rows [][]string
+	for _, m := range append(report.Months, report.Total) {
+		rows = append(rows, []string{
+			m.Period,
+			fmt.Sprintf("%.1f", m.Energy),
+			optional(m.Flow, "%.1f"),
+			optional(m.DeltaT, "%.1f"),
+			optional(m.DegreeDays, "%.1f"),
+			optional(m.EnergyPerDegreeDay, "%.1f"),
+			component(m, "effectCost"),
+			component(m, "energyCost"),
+			component(m, "flowCost"),
+			formatAmount(m.TotalExclVAT),
+			optional(m.CostPerKWh, "%.4f"),
+		})
+	}
+	return rows
+}
+
+func init() {
+	heatCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	heatCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	heatCmd.Flags().Int("energy-series", 0, "Energy series ID (detected if not set)")
+	heatCmd.Flags().Int("flow-series", 0, "Flow series ID (detected if not set)")
+	heatCmd.Flags().String("temperatures", "", "Daily outdoor temperature file (.csv)")
+	heatCmd.Flags().Float64("heating-base", eon.DefaultHeatingBase, "Base temperature of heating degree-days (°C)")
+	heatCmd.Flags().Float64("cooling-base", eon.DefaultCoolingBase, "Base temperature of cooling degree-days (°C)")
+	heatCmd.Flags().String("timezone", "Local", "Time zone for months and temperature dates")
+	heatCmd.Flags().String("output", "table", "Output format: table, csv, json")
+	_ = heatCmd.MarkFlagRequired("from")
+	_ = heatCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(heatCmd)
+}
@@ -1 +1,53 @@
 package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// printPaths lists written files, one per line
+func printPaths(cmd *cobra.Command, paths []string) {
+	for _, path := range paths {
+		fmt.Fprintln(cmd.OutOrStdout(), path)
+	}
+}
+
+// printTable writes rows as aligned, tab-separated columns
+func printTable(cmd *cobra.Command, headers []string, rows [][]string) {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t")+"\t")
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t")+"\t")
+	}
+	_ = w.Flush()
+}
+
+// formatAmount formats a monetary amount with two decimals
+func formatAmount(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+// dateFlag parses a YYYY-MM-DD flag, returning nil if it is unset
+func dateFlag(cmd *cobra.Command, name string) *time.Time {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	cobra.CheckErr(err)
+	return &t
+}
+
+// requiredDateFlag parses a YYYY-MM-DD flag that must be set
+func requiredDateFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("--%s is required", name)
+	}
+	return time.Parse(time.DateOnly, value)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -18,7 +19,24 @@ Resolution options:
   - quarter: 15-minute intervals (requires from/to, max 3 months)
   - hour: Hourly values (requires from/to, max 1 year)
   - day: Daily values
-  - month: Monthly values`,
+  - month: Monthly values
+
+Gap filling:
+  --fill fills missing values with none, zero, forward, linear or profile
+  (average of the same weekday and hour). Filled values are flagged as
+  estimated in JSON output unless the series is also resampled.
+
+Client-side resampling:
+  --bucket aggregates the series into hour, day, week, isoweek, month or year
+  buckets, or any custom duration such as 6h or 48h, using --agg (sum, mean,
+  min, max, count) in the --timezone time zone.
+
+Output formats:
+  - json: JSON document (default)
+  - influx: InfluxDB line protocol tagged with installation metadata
+  - openmetrics: OpenMetrics text exposition tagged with installation metadata
+  - parquet: Parquet files below --dir, partitioned by installation and month
+  - arrow: Arrow IPC files below --dir, partitioned like parquet`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		seriesID, err := strconv.Atoi(args[0])
@@ -28,6 +46,11 @@ Resolution options:
 		toFlag, _ := cmd.Flags().GetString("to")
 		resolution, _ := cmd.Flags().GetString("resolution")
 		includeMissing, _ := cmd.Flags().GetBool("include-missing")
+		output, _ := cmd.Flags().GetString("output")
+		bucket, _ := cmd.Flags().GetString("bucket")
+		agg, _ := cmd.Flags().GetString("agg")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		fill, _ := cmd.Flags().GetString("fill")
 
 		var from, to time.Time
 		if fromFlag != "" {
@@ -48,7 +71,54 @@ Resolution options:
 		)
 		cobra.CheckErr(err)
 
-		gout.MustPrint(measurements)
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		var filled *eon.FilledMeasurementsWrapper
+		if fill != "" {
+			result, err := eon.FillGaps(measurements, eon.FillStrategy(fill), loc)
+			cobra.CheckErr(err)
+
+			filled = &result
+			measurements = result.Wrapper()
+		}
+
+		if bucket != "" {
+			measurements, err = eon.Resample(measurements, eon.Bucket(bucket), eon.Aggregation(agg), loc)
+			cobra.CheckErr(err)
+			filled = nil
+		}
+
+		switch output {
+		case "json":
+			if filled != nil {
+				gout.MustPrint(filled)
+			} else {
+				gout.MustPrint(measurements)
+			}
+		case "influx", "openmetrics", "parquet", "arrow":
+			meta, err := eon.GetSeriesMetadata(clientInstance, seriesID)
+			cobra.CheckErr(err)
+
+			switch output {
+			case "influx":
+				cobra.CheckErr(eon.WriteLineProtocol(cmd.OutOrStdout(), measurements, meta.Tags()))
+			case "openmetrics":
+				cobra.CheckErr(eon.WriteOpenMetrics(cmd.OutOrStdout(), measurements, meta.Tags()))
+			case "parquet":
+				dir, _ := cmd.Flags().GetString("dir")
+				paths, err := eon.WriteMeasurementsParquet(dir, eon.MeasurementRows(measurements, meta))
+				cobra.CheckErr(err)
+				printPaths(cmd, paths)
+			case "arrow":
+				dir, _ := cmd.Flags().GetString("dir")
+				paths, err := eon.WriteMeasurementsArrow(dir, eon.MeasurementRows(measurements, meta))
+				cobra.CheckErr(err)
+				printPaths(cmd, paths)
+			}
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
 	},
 }
 
@@ -57,6 +127,12 @@ func init() {
 	measurementsCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
 	measurementsCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour, day, month")
 	measurementsCmd.Flags().Bool("include-missing", false, "Fill in missing values")
+	measurementsCmd.Flags().String("fill", "", "Fill missing values: none, zero, forward, linear, profile")
+	measurementsCmd.Flags().String("bucket", "", "Resample into buckets: hour, day, week, isoweek, month, year or a duration (e.g. 6h)")
+	measurementsCmd.Flags().String("agg", "sum", "Bucket aggregation: sum, mean, min, max, count")
+	measurementsCmd.Flags().String("timezone", "Local", "Time zone used for bucket boundaries and profiles (e.g. Europe/Stockholm)")
+	measurementsCmd.Flags().String("output", "json", "Output format: json, influx, openmetrics, parquet, arrow")
+	measurementsCmd.Flags().String("dir", ".", "Output directory for parquet and arrow files")
 
 	rootCmd.AddCommand(measurementsCmd)
 }
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var nettingCmd = &cobra.Command{
+	Use:   "netting <installation-id>",
+	Short: "Net production against consumption of a prosumer installation",
+	Long: `Pair the production and consumption series of an installation with solar
+or other own production, and compute self-consumption, export and import per
+interval and month, together with the self-sufficiency ratio (share of
+consumption covered by own production).
+
+Monthly production revenue is matched from the production costs of the
+installation, or of --cost-installation when production is billed on a
+separate installation. Both series are expected to be gross values.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		consumptionSeries, _ := cmd.Flags().GetInt("consumption-series")
+		productionSeries, _ := cmd.Flags().GetInt("production-series")
+		costInstallation, _ := cmd.Flags().GetString("cost-installation")
+		noRevenue, _ := cmd.Flags().GetBool("no-revenue")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		from, err := requiredDateFlag(cmd, "from")
+		cobra.CheckErr(err)
+		to, err := requiredDateFlag(cmd, "to")
+		cobra.CheckErr(err)
+
+		report, err := eon.GetNetting(clientInstance, args[0], eon.Resolution(resolution), from, to, eon.NettingOptions{
+			ConsumptionSeriesID: consumptionSeries,
+			ProductionSeriesID:  productionSeries,
+			CostInstallation:    costInstallation,
+			NoRevenue:           noRevenue,
+			Location:            loc,
+		})
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printNetting(cmd, report)
+		case "csv":
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write([]string{"time", "production_kwh", "consumption_kwh", "self_consumption_kwh", "export_kwh", "import_kwh"}))
+			for _, i := range report.Intervals {
+				cobra.CheckErr(w.Write([]string{
+					i.Time.In(loc).Format(time.RFC3339),
+					fmt.Sprintf("%.3f", i.Production),
+					fmt.Sprintf("%.3f", i.Consumption),
+					fmt.Sprintf("%.3f", i.SelfConsumption),
+					fmt.Sprintf("%.3f", i.Export),
+					fmt.Sprintf("%.3f", i.Import),
+				}))
+			}
+			w.Flush()
+			cobra.CheckErr(w.Error())
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+func init() {
+	nettingCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	nettingCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	nettingCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour, day")
+	nettingCmd.Flags().Int("consumption-series", 0, "Consumption series ID (detected if not set)")
+	nettingCmd.Flags().Int("production-series", 0, "Production series ID (detected if not set)")
+	nettingCmd.Flags().String("cost-installation", "", "Installation billed for production, if not the same")
+	nettingCmd.Flags().Bool("no-revenue", false, "Don't match production revenue from costs")
+	nettingCmd.Flags().String("timezone", "Local", "Time zone for months")
+	nettingCmd.Flags().String("output", "table", "Output format: table (per month), csv (per interval), json")
+	_ = nettingCmd.MarkFlagRequired("from")
+	_ = nettingCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(nettingCmd)
+}
+
+// printNetting prints the netting per month followed by the total
+func printNetting(cmd *cobra.Command, report eon.NettingReport) {
+	row := func(p eon.NettingPeriod) []string {
+		revenue, perKWh := "-", "-"
+		if p.Revenue != nil {
+			revenue = formatAmount(*p.Revenue)
+		}
+		if p.RevenuePerKWh != nil {
+			perKWh = fmt.Sprintf("%.4f", *p.RevenuePerKWh)
+		}
+		return []string{
+			p.Period,
+			fmt.Sprintf("%.1f", p.Production),
+			fmt.Sprintf("%.1f", p.Consumption),
+			fmt.Sprintf("%.1f", p.SelfConsumption),
+			fmt.Sprintf("%.1f", p.Export),
+			fmt.Sprintf("%.1f", p.Import),
+			fmt.Sprintf("%.1f%%", p.SelfSufficiency*100),
+			revenue,
+			perKWh,
+		}
+	}
+
+	var rows [][]string
+	for _, p := range report.Months {
+		rows = append(rows, row(p))
+	}
+	rows = append(rows, row(report.Total))
+	printTable(cmd, []string{"MONTH", "PRODUCTION (kWh)", "CONSUMPTION (kWh)", "SELF-CONSUMED", "EXPORT", "IMPORT", "SELF-SUFFICIENCY", "REVENUE", "REVENUE/kWh"}, rows)
+
+	if report.Unpaired > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %d intervals without both production and consumption\n", report.Unpaired)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var normalizeCmd = &cobra.Command{
+	Use:   "normalize <series-id>",
+	Short: "Weather-normalise daily or monthly consumption",
+	Long: `Normalise the consumption of a series to normal weather with heating and
+cooling degree-days from a daily outdoor temperature file (CSV with date and
+temperature columns, hourly readings are averaged per day).
+
+Daily consumption is fitted against the degree-days, and each day corrected
+for the difference between its degree-days and the normal degree-days of the
+calendar day. Normals are the average of each calendar day over all years of
+--temperatures, or are read from a separate --normals file.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		temperaturesFile, _ := cmd.Flags().GetString("temperatures")
+		normalsFile, _ := cmd.Flags().GetString("normals")
+		heatingBase, _ := cmd.Flags().GetFloat64("heating-base")
+		coolingBase, _ := cmd.Flags().GetFloat64("cooling-base")
+		period, _ := cmd.Flags().GetString("period")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		temperatures, err := eon.LoadTemperatureFile(temperaturesFile, loc)
+		cobra.CheckErr(err)
+
+		opts := eon.NormalizeOptions{
+			DegreeDays: eon.DegreeDayOptions{HeatingBase: heatingBase, CoolingBase: coolingBase},
+			Location:   loc,
+		}
+		if normalsFile != "" {
+			opts.Normals, err = eon.LoadTemperatureFile(normalsFile, loc)
+			cobra.CheckErr(err)
+		}
+
+		from, err := requiredDateFlag(cmd, "from")
+		cobra.CheckErr(err)
+		to, err := requiredDateFlag(cmd, "to")
+		cobra.CheckErr(err)
+
+		report, err := eon.GetNormalized(clientInstance, seriesID, from, to, temperatures, opts)
+		cobra.CheckErr(err)
+
+		var rows [][]string
+		switch period {
+		case "day":
+			for _, d := range report.Days {
+				rows = append(rows, normalizedRow(d.Date.Format(time.DateOnly), d.Consumption, d.Normalized, d.Heating, d.NormalHeating, d.Cooling, d.NormalCooling))
+			}
+		case "month":
+			for _, p := range report.Months {
+				rows = append(rows, normalizedRow(p.Period, p.Consumption, p.Normalized, p.Heating, p.NormalHeating, p.Cooling, p.NormalCooling))
+			}
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported period: %s (expected day or month)", period))
+		}
+		headers := []string{"PERIOD", "CONSUMPTION", "NORMALIZED", "HDD", "NORMAL HDD", "CDD", "NORMAL CDD"}
+
+		switch output {
+		case "table":
+			t := report.Total
+			rows = append(rows, normalizedRow(t.Period, t.Consumption, t.Normalized, t.Heating, t.NormalHeating, t.Cooling, t.NormalCooling))
+			m := report.Model
+			fmt.Fprintf(cmd.OutOrStdout(), "Model: %.2f %s/day + %.3f per HDD + %.3f per CDD (R² %.2f, %d days)\n\n",
+				m.Base, report.Unit, m.Heating, m.Cooling, m.R2, m.Days)
+			printTable(cmd, headers, rows)
+
+			if report.Unmatched > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %d days without temperature or normal\n", report.Unmatched)
+			}
+		case "csv":
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write(headers))
+			cobra.CheckErr(w.WriteAll(rows))
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+// normalizedRow formats a day or period of a normalization report
+func normalizedRow(period string, values ...float64) []string {
+	row := []string{period}
+	for _, v := range values {
+		row = append(row, fmt.Sprintf("%.2f", v))
+	}
+	return row
+}
+
+func init() {
+	normalizeCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	normalizeCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	normalizeCmd.Flags().String("temperatures", "", "Daily outdoor temperature file (.csv)")
+	normalizeCmd.Flags().String("normals", "", "Normal daily temperature file (.csv), averaged from --temperatures if not set")
+	normalizeCmd.Flags().Float64("heating-base", eon.DefaultHeatingBase, "Base temperature of heating degree-days (°C)")
+	normalizeCmd.Flags().Float64("cooling-base", eon.DefaultCoolingBase, "Base temperature of cooling degree-days (°C)")
+	normalizeCmd.Flags().String("period", "month", "Period: day, month")
+	normalizeCmd.Flags().String("timezone", "Local", "Time zone for days and temperature dates")
+	normalizeCmd.Flags().String("output", "table", "Output format: table, csv, json")
+	_ = normalizeCmd.MarkFlagRequired("temperatures")
+	_ = normalizeCmd.MarkFlagRequired("from")
+	_ = normalizeCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(normalizeCmd)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var peaksCmd = &cobra.Command{
+	Use:   "peaks <series-id>",
+	Short: "Analyse peak demand of a measurement series",
+	Long: `Find the highest hourly power peaks of each month of a quarter or hour
+series (quarters are summed into hours), the average of the top peaks (as used by effect tariffs), and the utilisation
+of the installation's fuse (safety level).
+
+Use --distinct-days to only count the highest peak of each day.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		var opts eon.PeakOptions
+		opts.Top, _ = cmd.Flags().GetInt("top")
+		opts.DistinctDays, _ = cmd.Flags().GetBool("distinct-days")
+		opts.Voltage, _ = cmd.Flags().GetFloat64("voltage")
+		opts.Location, err = time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		var from, to time.Time
+		if t := dateFlag(cmd, "from"); t != nil {
+			from = *t
+		}
+		if t := dateFlag(cmd, "to"); t != nil {
+			to = *t
+		}
+
+		report, err := eon.GetPeaks(clientInstance, seriesID, eon.Resolution(resolution), from, to, opts)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printPeaks(cmd, report, opts.Location)
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+func init() {
+	peaksCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	peaksCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	peaksCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour")
+	peaksCmd.Flags().Int("top", 3, "Number of peaks per month")
+	peaksCmd.Flags().Bool("distinct-days", false, "Only count the highest peak of each day")
+	peaksCmd.Flags().Float64("voltage", 400, "Line-to-line voltage used for the fuse capacity")
+	peaksCmd.Flags().String("timezone", "Local", "Time zone for months and days (e.g. Europe/Stockholm)")
+	peaksCmd.Flags().String("output", "table", "Output format: table, json")
+
+	rootCmd.AddCommand(peaksCmd)
+}
+
+// printPeaks prints the peaks of each month with their average and fuse utilisation
+func printPeaks(cmd *cobra.Command, report eon.PeakReport, loc *time.Location) {
+	utilisation := func(u *float64) string {
+		if u == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", *u*100)
+	}
+
+	var rows [][]string
+	for _, month := range report.Months {
+		var peaks []string
+		for _, p := range month.Peaks {
+			peaks = append(peaks, fmt.Sprintf("%.2f @ %s", p.Power, p.Time.In(loc).Format("01-02 15:04")))
+		}
+		rows = append(rows, []string{
+			month.Month.In(loc).Format("2006-01"),
+			fmt.Sprintf("%.2f", month.Max.Power),
+			fmt.Sprintf("%.2f", month.Average),
+			utilisation(month.FuseUtilisation),
+			strings.Join(peaks, ", "),
+		})
+	}
+	printTable(cmd, []string{"MONTH", "MAX (kW)", fmt.Sprintf("AVG TOP %d (kW)", report.Top), "FUSE", "PEAKS (kW)"}, rows)
+
+	if report.FuseCapacity != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nFuse capacity: %.2f kW, highest utilisation: %s\n", *report.FuseCapacity, utilisation(report.FuseUtilisation))
+	}
+}
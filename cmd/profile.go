@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile <series-id>",
+	Short: "Analyse the load profile of a measurement series",
+	Long: `Compute the average power by hour of day for every weekday, weekdays vs
+weekends and each season of a quarter or hour series, along with the night
+baseload and load factor.
+
+The heatmap output shades each weekday and hour by its average power, from
+' ' (lowest) to '@' (highest). The csv output has one row per hour of day.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		var opts eon.ProfileOptions
+		opts.NightStart, _ = cmd.Flags().GetInt("night-start")
+		opts.NightEnd, _ = cmd.Flags().GetInt("night-end")
+		opts.Location, err = time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		var from, to time.Time
+		if t := dateFlag(cmd, "from"); t != nil {
+			from = *t
+		}
+		if t := dateFlag(cmd, "to"); t != nil {
+			to = *t
+		}
+
+		profile, err := eon.GetProfile(clientInstance, seriesID, eon.Resolution(resolution), from, to, opts)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "heatmap":
+			printHeatmap(cmd, profile)
+		case "csv":
+			headers, rows := profileRows(profile)
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write(headers))
+			cobra.CheckErr(w.WriteAll(rows))
+		case "json":
+			gout.MustPrint(profile)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+func init() {
+	profileCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	profileCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	profileCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour")
+	profileCmd.Flags().Int("night-start", 0, "First hour of the night window used for the baseload")
+	profileCmd.Flags().Int("night-end", 5, "Hour the night window ends (exclusive)")
+	profileCmd.Flags().String("timezone", "Local", "Time zone for hours and weekdays (e.g. Europe/Stockholm)")
+	profileCmd.Flags().String("output", "heatmap", "Output format: heatmap, csv, json")
+
+	rootCmd.AddCommand(profileCmd)
+}
+
+var weekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// printHeatmap prints the weekday by hour heatmap followed by the profile key figures
+func printHeatmap(cmd *cobra.Command, profile eon.Profile) {
+	const shades = " .:-=+*#%@"
+
+	low, high := profile.Peak, 0.0
+	for _, day := range profile.Heatmap {
+		for _, v := range day {
+			if v != nil {
+				low, high = min(low, *v), max(high, *v)
+			}
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprint(out, "     ")
+	for hour := 0; hour < 24; hour += 3 {
+		fmt.Fprintf(out, "%-3d", hour)
+	}
+	fmt.Fprintln(out)
+
+	for i, day := range profile.Heatmap {
+		var row strings.Builder
+		for _, v := range day {
+			switch {
+			case v == nil:
+				row.WriteByte('?')
+			case high == low:
+				row.WriteByte(shades[len(shades)-1])
+			default:
+				row.WriteByte(shades[int((*v-low)/(high-low)*float64(len(shades)-1))])
+			}
+		}
+		fmt.Fprintf(out, "%s  %s\n", weekdays[i], row.String())
+	}
+
+	fmt.Fprintf(out, "\nScale: ' ' = %.2f kW, '@' = %.2f kW, '?' = no data\n", low, high)
+	fmt.Fprintf(out, "Average: %.2f kW, peak: %.2f kW, load factor: %.2f\n", profile.Average, profile.Peak, profile.LoadFactor)
+	fmt.Fprintf(out, "Night baseload: %.2f kW (%.1f%% of average)\n", profile.NightBaseload, profile.BaseloadShare*100)
+}
+
+// profileRows returns the csv headers and one row per hour of day
+func profileRows(profile eon.Profile) ([]string, [][]string) {
+	value := func(p eon.HourProfile, hour int) string {
+		if p[hour] == nil {
+			return ""
+		}
+		return fmt.Sprintf("%.3f", *p[hour])
+	}
+
+	headers := []string{"hour", "all", "weekday", "weekend"}
+	for _, day := range weekdays {
+		headers = append(headers, strings.ToLower(day))
+	}
+	for _, season := range profile.Seasons {
+		headers = append(headers, season.Season)
+	}
+
+	var rows [][]string
+	for hour := 0; hour < 24; hour++ {
+		row := []string{strconv.Itoa(hour), value(profile.Hours, hour), value(profile.Weekday, hour), value(profile.Weekend, hour)}
+		for _, day := range profile.Heatmap {
+			row = append(row, value(day, hour))
+		}
+		for _, season := range profile.Seasons {
+			row = append(row, value(season.Hours, hour))
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
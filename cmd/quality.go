@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var qualityCmd = &cobra.Command{
+	Use:   "quality <series-id>",
+	Short: "Report data quality of a measurement series",
+	Long: `Analyse a measurement series for missing intervals, duplicate timestamps,
+negative and outlier values, DST anomalies and staleness.
+
+The command exits with code 2 if any threshold is violated.
+Set a threshold to a negative value to disable the check.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		fromFlag, _ := cmd.Flags().GetString("from")
+		toFlag, _ := cmd.Flags().GetString("to")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+
+		opts := eon.DefaultQualityOptions()
+		opts.MaxMissingRatio, _ = cmd.Flags().GetFloat64("max-missing")
+		opts.MaxDuplicates, _ = cmd.Flags().GetInt("max-duplicates")
+		opts.MaxNegatives, _ = cmd.Flags().GetInt("max-negatives")
+		opts.MaxOutliers, _ = cmd.Flags().GetInt("max-outliers")
+		opts.MaxDSTAnomalies, _ = cmd.Flags().GetInt("max-dst-anomalies")
+		opts.MaxAge, _ = cmd.Flags().GetDuration("max-age")
+		opts.OutlierThreshold, _ = cmd.Flags().GetFloat64("outlier-threshold")
+
+		opts.Location, err = time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		var from, to time.Time
+		if fromFlag != "" {
+			from, err = time.Parse(time.DateOnly, fromFlag)
+			cobra.CheckErr(err)
+		}
+		if toFlag != "" {
+			to, err = time.Parse(time.DateOnly, toFlag)
+			cobra.CheckErr(err)
+		}
+
+		meta, err := eon.GetSeriesMetadata(clientInstance, seriesID)
+		cobra.CheckErr(err)
+
+		measurements, err := clientInstance.GetMeasurements(seriesID, eon.Resolution(resolution), from, to, true)
+		cobra.CheckErr(err)
+		if measurements.Resolution == "" {
+			measurements.Resolution = resolution
+		}
+
+		report, err := eon.AnalyzeQuality(measurements, meta.Series.LastUpdate.Time, opts)
+		cobra.CheckErr(err)
+
+		gout.MustPrint(report)
+
+		if !report.OK() {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Quality thresholds violated: %s\n", strings.Join(report.Violations, "; "))
+			os.Exit(2)
+		}
+	},
+}
+
+func init() {
+	qualityCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	qualityCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	qualityCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour, day, month")
+	qualityCmd.Flags().String("timezone", "Local", "Time zone used for calendar and DST checks (e.g. Europe/Stockholm)")
+	qualityCmd.Flags().Float64("max-missing", 0.01, "Maximum share of missing intervals (0-1)")
+	qualityCmd.Flags().Int("max-duplicates", 0, "Maximum number of duplicate timestamps")
+	qualityCmd.Flags().Int("max-negatives", 0, "Maximum number of negative values")
+	qualityCmd.Flags().Int("max-outliers", -1, "Maximum number of outliers")
+	qualityCmd.Flags().Int("max-dst-anomalies", 0, "Maximum number of DST anomalies")
+	qualityCmd.Flags().Duration("max-age", 72*time.Hour, "Maximum age of the series' last update (0 to disable)")
+	qualityCmd.Flags().Float64("outlier-threshold", 3.5, "Modified z-score above which a value is an outlier")
+
+	rootCmd.AddCommand(qualityCmd)
+}
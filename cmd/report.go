@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Get a consumption and cost report across all installations",
+	Long: `Fetch consumption and costs of every installation for a period and the same
+period one year earlier, grouped by an installation attribute.
+
+Group options: priceArea, gridArea, city, category, business
+
+Consumption is reported in kWh and costs exclude VAT unless stated.
+Shares are fractions of the portfolio total and deltas are year-over-year
+changes in percent.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		output, _ := cmd.Flags().GetString("output")
+
+		from, err := requiredDateFlag(cmd, "from")
+		cobra.CheckErr(err)
+		to, err := requiredDateFlag(cmd, "to")
+		cobra.CheckErr(err)
+
+		report, err := eon.GetPortfolioReport(clientInstance, eon.PortfolioGroupBy(groupBy), from, to)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printTable(cmd, portfolioHeaders, portfolioRows(report))
+			for _, inst := range report.Installations {
+				for _, e := range inst.Errors {
+					fmt.Fprintf(cmd.ErrOrStderr(), "warning: installation %s: %s\n", inst.Installation.ID, e)
+				}
+			}
+		case "csv":
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write(portfolioHeaders))
+			cobra.CheckErr(w.WriteAll(portfolioRows(report)))
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+var portfolioHeaders = []string{
+	"GROUP", "INSTALLATIONS",
+	"CONSUMPTION (kWh)", "SHARE", "PREVIOUS (kWh)", "DELTA",
+	"COST", "COST INCL VAT", "SHARE", "PREVIOUS COST", "DELTA",
+}
+
+// portfolioRows returns one row per group followed by the total
+func portfolioRows(report eon.PortfolioReport) [][]string {
+	percent := func(v *float64) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%+.1f%%", *v)
+	}
+
+	var rows [][]string
+	for _, g := range append(report.Groups, report.Total) {
+		rows = append(rows, []string{
+			g.Key,
+			fmt.Sprint(g.Installations),
+			fmt.Sprintf("%.1f", g.Consumption),
+			fmt.Sprintf("%.1f%%", g.ConsumptionShare*100),
+			fmt.Sprintf("%.1f", g.PreviousConsumption),
+			percent(g.ConsumptionDelta),
+			formatAmount(g.Cost),
+			formatAmount(g.CostInclVAT),
+			fmt.Sprintf("%.1f%%", g.CostShare*100),
+			formatAmount(g.PreviousCost),
+			percent(g.CostDelta),
+		})
+	}
+	return rows
+}
+
+func init() {
+	reportCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	reportCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	reportCmd.Flags().String("group-by", "priceArea", "Group by: priceArea, gridArea, city, category, business")
+	reportCmd.Flags().String("output", "table", "Output format: table, csv, json")
+	_ = reportCmd.MarkFlagRequired("from")
+	_ = reportCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(reportCmd)
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/drewstinnett/gout/v2"
@@ -23,10 +25,55 @@ or can be provided via command-line flags.`,
 		clientID, _ := cmd.Flags().GetString("client-id")
 		clientSecret, _ := cmd.Flags().GetString("client-secret")
 
+		cacheDir, _ := cmd.Flags().GetString("cache-dir")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		refreshCache, _ := cmd.Flags().GetBool("refresh-cache")
+
+		if cacheDir == "" {
+			cacheDir = os.Getenv("EON_CACHE_DIR")
+		}
+
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		logFormat, _ := cmd.Flags().GetString("log-format")
+
+		level := slog.LevelWarn
+		if verbose {
+			level = slog.LevelDebug
+		}
+		handlerOpts := &slog.HandlerOptions{Level: level}
+		var handler slog.Handler
+		switch logFormat {
+		case "text":
+			handler = slog.NewTextHandler(os.Stderr, handlerOpts)
+		case "json":
+			handler = slog.NewJSONHandler(os.Stderr, handlerOpts)
+		default:
+			return fmt.Errorf("invalid log format %q, expected json or text", logFormat)
+		}
+
+		opts := []eon.Option{eon.WithLogger(slog.New(handler))}
+
+		// Responses are only cached when asked for, so long-running commands like serve and watch
+		// see fresh data by default
+		if cacheDir != "" {
+			cache, err := eon.NewDiskCache(cacheDir)
+			if err != nil {
+				return fmt.Errorf("failed to open cache: %w", err)
+			}
+			opts = append(opts, eon.WithCache(cache, eon.CacheOptions{}))
+		}
+
 		if clientID != "" && clientSecret != "" {
-			clientInstance = eon.NewWithCredentials(clientID, clientSecret)
+			clientInstance = eon.NewWithCredentials(clientID, clientSecret, opts...)
 		} else {
-			clientInstance = eon.New()
+			clientInstance = eon.New(opts...)
+		}
+
+		switch {
+		case noCache:
+			clientInstance = eon.WithCacheMode(clientInstance, eon.CacheBypass)
+		case refreshCache:
+			clientInstance = eon.WithCacheMode(clientInstance, eon.CacheRefresh)
 		}
 		return nil
 	},
@@ -44,4 +91,9 @@ func init() {
 
 	rootCmd.PersistentFlags().String("client-id", "", "Eon API client ID (env: CLIENT_ID)")
 	rootCmd.PersistentFlags().String("client-secret", "", "Eon API client secret (env: CLIENT_SECRET)")
+	rootCmd.PersistentFlags().String("cache-dir", "", "Cache API responses in a directory across runs, uncached if empty (env: EON_CACHE_DIR)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Bypass the response cache")
+	rootCmd.PersistentFlags().Bool("refresh-cache", false, "Fetch fresh responses and update the cache")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Log requests, token refreshes and retries to stderr")
+	rootCmd.PersistentFlags().String("log-format", "text", "Log format: text, json")
 }
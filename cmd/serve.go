@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the Eon API as a REST gateway",
+	Long: `Expose installations, measurement series, measurements and typed costs as a
+small REST API, so tools in other languages don't need to handle OAuth.
+
+Routes:
+  GET /installations[?id=...]
+  GET /installations/measurement-series
+  GET /measurements/{id}?resolution=hour&from=YYYY-MM-DD&to=YYYY-MM-DD&includeMissing=false
+  GET /costs/{installation}?from=YYYY-MM-DD&to=YYYY-MM-DD
+  GET /openapi.json
+
+Long measurement ranges are fetched in chunks. Callers must send one of the
+--api-key keys (or EON_API_KEYS, comma-separated) in the X-API-Key header or as
+a bearer token; without keys the gateway is open. Send Cache-Control: no-cache
+to bypass cached responses.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, _ := cmd.Flags().GetString("addr")
+		apiKeys, _ := cmd.Flags().GetStringSlice("api-key")
+		corsOrigins, _ := cmd.Flags().GetStringSlice("cors-origin")
+		cacheTTL, _ := cmd.Flags().GetDuration("cache-ttl")
+		printOpenAPI, _ := cmd.Flags().GetBool("openapi")
+
+		if printOpenAPI {
+			gout.MustPrint(eon.OpenAPI())
+			return
+		}
+
+		if env := os.Getenv("EON_API_KEYS"); env != "" && len(apiKeys) == 0 {
+			apiKeys = strings.Split(env, ",")
+		}
+
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           eon.NewServer(clientInstance, eon.ServerOptions{APIKeys: apiKeys, CORSOrigins: corsOrigins, CacheTTL: cacheTTL}),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		if len(apiKeys) == 0 {
+			fmt.Fprintln(cmd.ErrOrStderr(), "warning: no API keys configured, the gateway is open to anyone who can reach it")
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "listening on %s\n", addr)
+		cobra.CheckErr(server.ListenAndServe())
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", ":8080", "Address to listen on")
+	serveCmd.Flags().StringSlice("api-key", nil, "API key accepted from callers (repeatable, env: EON_API_KEYS)")
+	serveCmd.Flags().StringSlice("cors-origin", nil, "Origin allowed to call the gateway from browsers, * for any (repeatable)")
+	serveCmd.Flags().Duration("cache-ttl", 5*time.Minute, "How long responses are cached, 0 to disable")
+	serveCmd.Flags().Bool("openapi", false, "Print the OpenAPI document and exit")
+
+	rootCmd.AddCommand(serveCmd)
+}
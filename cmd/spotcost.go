@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var spotCostCmd = &cobra.Command{
+	Use:   "spot-cost <series-id>",
+	Short: "Estimate hourly spot cost of a measurement series",
+	Long: `Multiply the consumption of each interval of a quarter or hour series with
+the spot price of the installation's price area (SE1-SE4) from a price file.
+
+Price files are .csv with time,area,price or time,SE1,SE2,SE3,SE4 columns,
+or .json arrays of {"time", "area", "price"} objects. Use --mwh for prices
+per MWh as published by Nord Pool.
+
+The table output sums intervals per day, csv lists every interval.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		seriesID, err := strconv.Atoi(args[0])
+		cobra.CheckErr(err)
+
+		pricesFile, _ := cmd.Flags().GetString("prices")
+		mwh, _ := cmd.Flags().GetBool("mwh")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		output, _ := cmd.Flags().GetString("output")
+
+		loc, err := time.LoadLocation(timezone)
+		cobra.CheckErr(err)
+
+		prices, err := eon.LoadPriceFile(pricesFile, eon.PriceFileOptions{Location: loc, MWh: mwh})
+		cobra.CheckErr(err)
+
+		var from, to time.Time
+		if t := dateFlag(cmd, "from"); t != nil {
+			from = *t
+		}
+		if t := dateFlag(cmd, "to"); t != nil {
+			to = *t
+		}
+
+		report, err := eon.GetSpotCosts(clientInstance, seriesID, eon.Resolution(resolution), from, to, prices)
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printSpotCosts(cmd, report, loc)
+		case "csv":
+			w := csv.NewWriter(cmd.OutOrStdout())
+			cobra.CheckErr(w.Write([]string{"time", "consumption_kwh", "price", "cost"}))
+			for _, i := range report.Intervals {
+				price, cost := "", ""
+				if i.Price != nil {
+					price, cost = fmt.Sprintf("%.5f", *i.Price), fmt.Sprintf("%.4f", *i.Cost)
+				}
+				cobra.CheckErr(w.Write([]string{i.Time.In(loc).Format(time.RFC3339), fmt.Sprintf("%.3f", i.Consumption), price, cost}))
+			}
+			w.Flush()
+			cobra.CheckErr(w.Error())
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+func init() {
+	spotCostCmd.Flags().String("prices", "", "Price file (.csv or .json)")
+	spotCostCmd.Flags().Bool("mwh", false, "Prices in the file are per MWh")
+	spotCostCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	spotCostCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	spotCostCmd.Flags().String("resolution", "hour", "Resolution: quarter, hour")
+	spotCostCmd.Flags().String("timezone", "Local", "Time zone for days and price timestamps without offset")
+	spotCostCmd.Flags().String("output", "table", "Output format: table, csv, json")
+	_ = spotCostCmd.MarkFlagRequired("prices")
+
+	rootCmd.AddCommand(spotCostCmd)
+}
+
+// printSpotCosts prints consumption, cost and average prices per day followed by the total
+func printSpotCosts(cmd *cobra.Command, report eon.SpotCostReport, loc *time.Location) {
+	type day struct {
+		consumption, cost, prices float64
+		priced                    int
+	}
+
+	days := map[string]*day{}
+	var keys []string
+	for _, i := range report.Intervals {
+		if i.Price == nil {
+			continue
+		}
+		key := i.Time.In(loc).Format(time.DateOnly)
+		d, ok := days[key]
+		if !ok {
+			d = &day{}
+			days[key] = d
+			keys = append(keys, key)
+		}
+		d.consumption += i.Consumption
+		d.cost += *i.Cost
+		d.prices += *i.Price
+		d.priced++
+	}
+
+	row := func(name string, consumption, cost, spotAverage float64) []string {
+		average := "-"
+		if consumption != 0 {
+			average = fmt.Sprintf("%.4f", cost/consumption)
+		}
+		return []string{name, fmt.Sprintf("%.2f", consumption), formatAmount(cost), average, fmt.Sprintf("%.4f", spotAverage)}
+	}
+
+	var rows [][]string
+	for _, key := range keys {
+		d := days[key]
+		rows = append(rows, row(key, d.consumption, d.cost, d.prices/float64(d.priced)))
+	}
+	rows = append(rows, row("total", report.Consumption, report.Cost, report.SpotAverage))
+	printTable(cmd, []string{"DAY", "CONSUMPTION (kWh)", "COST", "AVG PRICE", "SPOT AVG"}, rows)
+
+	if report.Unpriced > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %d intervals without a %s price\n", report.Unpriced, report.PriceArea)
+	}
+}
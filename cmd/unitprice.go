@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drewstinnett/gout/v2"
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var unitPriceCmd = &cobra.Command{
+	Use:   "unit-price <installation-id>",
+	Short: "Get the effective price per unit of consumption",
+	Long: `Join monthly costs with monthly consumption of an installation and compute
+the effective price per unit, in total and per cost component, excluding and
+including VAT.
+
+The consumption series is detected automatically unless --series is given.
+Energy is reported per kWh, other series in their own unit. Months without
+both costs and consumption are left out.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		installationID := args[0]
+
+		seriesID, _ := cmd.Flags().GetInt("series")
+		output, _ := cmd.Flags().GetString("output")
+
+		report, err := eon.GetUnitPrices(clientInstance, installationID, seriesID, dateFlag(cmd, "from"), dateFlag(cmd, "to"))
+		cobra.CheckErr(err)
+
+		switch output {
+		case "table":
+			printUnitPrices(cmd, report)
+		case "json":
+			gout.MustPrint(report)
+		default:
+			cobra.CheckErr(fmt.Errorf("unsupported output format: %s", output))
+		}
+	},
+}
+
+func init() {
+	unitPriceCmd.Flags().String("from", "", "Start date (YYYY-MM-DD)")
+	unitPriceCmd.Flags().String("to", "", "End date (YYYY-MM-DD)")
+	unitPriceCmd.Flags().Int("series", 0, "Consumption measurement series ID (detected if omitted)")
+	unitPriceCmd.Flags().String("output", "table", "Output format: table, json")
+
+	rootCmd.AddCommand(unitPriceCmd)
+}
+
+// printUnitPrices prints unit prices per month followed by a per-component breakdown of the totals
+func printUnitPrices(cmd *cobra.Command, report eon.UnitPriceReport) {
+	unit := report.Unit
+	if unit == "" {
+		unit = "unit"
+	}
+
+	var rows [][]string
+	for _, p := range append(report.Months, report.Total) {
+		rows = append(rows, []string{
+			p.Period,
+			fmt.Sprintf("%.3f", p.Consumption),
+			fmt.Sprintf("%.4f", p.TotalExclVAT),
+			fmt.Sprintf("%.4f", p.TotalInclVAT),
+		})
+	}
+	printTable(cmd, []string{"MONTH", "CONSUMPTION (" + unit + ")", "EXCL VAT/" + unit, "INCL VAT/" + unit}, rows)
+
+	fmt.Fprintln(cmd.OutOrStdout())
+
+	rows = nil
+	for _, c := range report.Total.Components {
+		name := c.Component
+		if c.Detail {
+			name = "  " + name
+		}
+		rows = append(rows, []string{name, fmt.Sprintf("%.4f", c.ExclVAT), fmt.Sprintf("%.4f", c.InclVAT)})
+	}
+	printTable(cmd, []string{"COMPONENT", "EXCL VAT/" + unit, "INCL VAT/" + unit}, rows)
+}
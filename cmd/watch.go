@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/slimcdk/go-eon/eon"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch series and costs and send alerts",
+	Long: `Periodically evaluate the rules of the config file and notify about new
+violations through webhooks, email and commands.
+
+Rule types:
+  consumption  Hourly consumption of a series above threshold kWh
+  monthlyCost  Month-to-date cost of an installation above threshold
+  stale        Series not updated within maxAge
+
+Sent alerts are recorded in the state file, so each violation is only
+notified once, also across restarts. Use --once to check a single time,
+e.g. from cron.
+
+Example config (eon.json):
+  {
+    "watch": {
+      "interval": "15m",
+      "stateFile": "eon-state.json",
+      "rules": [
+        {"type": "consumption", "seriesId": 737605, "threshold": 50},
+        {"type": "monthlyCost", "installation": "735999163005019944", "threshold": 20000},
+        {"type": "stale", "seriesId": 737605, "maxAge": "48h"}
+      ],
+      "webhooks": [{"url": "https://hooks.example.com/eon"}],
+      "emails": [{"addr": "smtp.example.com:587", "username": "eon",
+                  "password": "${SMTP_PASSWORD}", "from": "eon@example.com",
+                  "to": ["ops@example.com"]}],
+      "commands": [{"command": "/usr/local/bin/on-alert"}]
+    }
+  }`,
+	Run: func(cmd *cobra.Command, args []string) {
+		once, _ := cmd.Flags().GetBool("once")
+
+		cfg, err := loadConfig(cmd)
+		cobra.CheckErr(err)
+
+		interval := time.Duration(cfg.Watch.Interval)
+		if interval == 0 {
+			interval = 15 * time.Minute
+		}
+		stateFile := cfg.Watch.StateFile
+		if stateFile == "" {
+			stateFile = "eon-state.json"
+		}
+
+		state, err := eon.LoadAlertState(stateFile)
+		cobra.CheckErr(err)
+
+		w := &eon.Watcher{
+			Client:    clientInstance,
+			Rules:     cfg.Watch.Rules,
+			Notifiers: cfg.Watch.notifiers(),
+			State:     state,
+		}
+
+		for {
+			alerts, err := w.Check(time.Now())
+			for _, alert := range alerts {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s: %s\n", time.Now().Format(time.RFC3339), alert.Rule, alert.Message)
+			}
+			if once {
+				cobra.CheckErr(err)
+				return
+			}
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", err)
+			}
+
+			time.Sleep(interval)
+		}
+	},
+}
+
+func init() {
+	watchCmd.Flags().String("config", "eon.json", "Path to the config file")
+	watchCmd.Flags().Bool("once", false, "Check once and exit")
+
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,215 @@
+package eon
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AnomalyOptions configures AnalyzeAnomalies
+type AnomalyOptions struct {
+	Threshold     float64        // Absolute z-score at which an interval is flagged, 3 if zero
+	Window        time.Duration  // Rolling window preceding each interval, 7 days if zero
+	SeasonalWeeks int            // Weeks of history for the hour-of-week baseline, 8 if zero
+	MinSamples    int            // Minimum number of earlier values for a score, 4 if zero
+	HighOnly      bool           // Only flag values above the baseline
+	Since         time.Time      // Only flag intervals at or after Since; earlier values serve as history
+	Location      *time.Location // Time zone for the hour-of-week baseline, UTC if nil
+}
+
+// Anomaly is a flagged interval. RollingScore is the z-score against the preceding window and
+// SeasonalScore the z-score against earlier values at the same time of week; either is nil
+// without enough history. Score is the weaker of the available scores.
+type Anomaly struct {
+	Time          time.Time `json:"time"`
+	Value         float64   `json:"value"`
+	RollingMean   float64   `json:"rollingMean"`
+	RollingScore  *float64  `json:"rollingScore"`
+	Expected      *float64  `json:"expected"` // Mean at the same time of week
+	SeasonalScore *float64  `json:"seasonalScore"`
+	Score         float64   `json:"score"`
+	Direction     string    `json:"direction"` // high or low
+}
+
+// AnomalyReport holds the flagged intervals of a series
+type AnomalyReport struct {
+	SeriesID   int       `json:"seriesId"`
+	Resolution string    `json:"resolution"`
+	Threshold  float64   `json:"threshold"`
+	Checked    int       `json:"checked"` // Number of intervals with a score
+	Anomalies  []Anomaly `json:"anomalies"`
+}
+
+// GetAnomalies fetches a series for [from, to] and flags anomalous intervals. The seasonal
+// history preceding from is fetched as well, so opts.Since is set to from.
+//
+// Example:
+//
+//	report, err := eon.GetAnomalies(client, 737605, eon.Hour, from, to, eon.AnomalyOptions{Threshold: 3, HighOnly: true})
+func GetAnomalies(c Client, seriesID int, resolution Resolution, from, to time.Time, opts AnomalyOptions) (AnomalyReport, error) {
+	opts = opts.withDefaults()
+
+	fetchFrom := from
+	if !from.IsZero() {
+		opts.Since = from
+		fetchFrom = from.AddDate(0, 0, -7*opts.SeasonalWeeks)
+	}
+
+	measurements, err := c.GetMeasurements(seriesID, resolution, fetchFrom.UTC(), to.UTC(), false)
+	if err != nil {
+		return AnomalyReport{}, err
+	}
+	if measurements.Resolution == "" {
+		measurements.Resolution = string(resolution)
+	}
+
+	return AnalyzeAnomalies(measurements, opts), nil
+}
+
+// withDefaults fills in zero options
+func (o AnomalyOptions) withDefaults() AnomalyOptions {
+	if o.Threshold == 0 {
+		o.Threshold = 3
+	}
+	if o.Window == 0 {
+		o.Window = 7 * 24 * time.Hour
+	}
+	if o.SeasonalWeeks == 0 {
+		o.SeasonalWeeks = 8
+	}
+	if o.MinSamples == 0 {
+		o.MinSamples = 4
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	return o
+}
+
+// AnalyzeAnomalies scores every interval against two baselines: the values of the preceding window
+// (rolling z-score, once the series covers a full window) and earlier values at the same local time
+// of week (seasonal baseline). An interval is flagged when every available score reaches the
+// threshold, so a value must be unusual both recently and for its time of week. Standard deviations
+// are floored at 1% of the mean, so that a jump after a constant history is still flagged.
+func AnalyzeAnomalies(m MeasurementsWrapper, opts AnomalyOptions) AnomalyReport {
+	opts = opts.withDefaults()
+
+	report := AnomalyReport{
+		SeriesID:   m.ID,
+		Resolution: m.Resolution,
+		Threshold:  opts.Threshold,
+		Anomalies:  []Anomaly{},
+	}
+
+	type point struct {
+		ts    time.Time
+		value float64
+	}
+	var points []point
+	for _, measurement := range m.Measurements {
+		if measurement.Value != nil {
+			points = append(points, point{measurement.TimeStamp.Time, *measurement.Value})
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].ts.Before(points[j].ts) })
+
+	seasonal := map[int][]point{} // Earlier values by minute of week
+	history := time.Duration(opts.SeasonalWeeks) * 7 * 24 * time.Hour
+
+	var lo int
+	var sum, sumSquares float64
+	for i, p := range points {
+		// Slide the rolling window to [p.ts - Window, p.ts)
+		for ; lo < i && points[lo].ts.Before(p.ts.Add(-opts.Window)); lo++ {
+			sum -= points[lo].value
+			sumSquares -= points[lo].value * points[lo].value
+		}
+
+		local := p.ts.In(opts.Location)
+		slot := ((int(local.Weekday())*24)+local.Hour())*60 + local.Minute()
+
+		if !p.ts.Before(opts.Since) {
+			anomaly := Anomaly{Time: p.ts, Value: p.value}
+			var scores []float64
+
+			// A partial window would compare the first day of a series with its night only
+			if n := i - lo; n >= opts.MinSamples && !points[0].ts.After(p.ts.Add(-opts.Window)) {
+				mean := sum / float64(n)
+				anomaly.RollingMean = mean
+				if z, ok := zScore(p.value, mean, sumSquares/float64(n)-mean*mean); ok {
+					anomaly.RollingScore = &z
+					scores = append(scores, z)
+				}
+			}
+
+			var values []float64
+			for _, s := range seasonal[slot] {
+				if !s.ts.Before(p.ts.Add(-history)) {
+					values = append(values, s.value)
+				}
+			}
+			if len(values) >= opts.MinSamples {
+				mean, variance := meanVariance(values)
+				anomaly.Expected = &mean
+				if z, ok := zScore(p.value, mean, variance); ok {
+					anomaly.SeasonalScore = &z
+					scores = append(scores, z)
+				}
+			}
+
+			if len(scores) > 0 {
+				report.Checked++
+
+				anomaly.Score = scores[0]
+				for _, z := range scores[1:] {
+					if (z > 0) != (anomaly.Score > 0) {
+						anomaly.Score = 0 // Scores disagree on the direction
+						break
+					}
+					if math.Abs(z) < math.Abs(anomaly.Score) {
+						anomaly.Score = z
+					}
+				}
+
+				anomaly.Direction = "high"
+				if anomaly.Score < 0 {
+					anomaly.Direction = "low"
+				}
+				if math.Abs(anomaly.Score) >= opts.Threshold && (!opts.HighOnly || anomaly.Score > 0) {
+					report.Anomalies = append(report.Anomalies, anomaly)
+				}
+			}
+		}
+
+		sum += p.value
+		sumSquares += p.value * p.value
+		seasonal[slot] = append(seasonal[slot], p)
+	}
+
+	return report
+}
+
+// zScore returns the z-score of value, flooring the standard deviation at 1% of the mean.
+// It returns false if both are zero.
+func zScore(value, mean, variance float64) (float64, bool) {
+	std := math.Max(math.Sqrt(math.Max(variance, 0)), 0.01*math.Abs(mean))
+	if std == 0 {
+		return 0, false
+	}
+	return (value - mean) / std, true
+}
+
+// meanVariance returns the mean and population variance of values
+func meanVariance(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, squares / float64(len(values))
+}
@@ -0,0 +1,131 @@
+package eon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// weeklyPattern returns hourly values for a number of weeks, 1 at night and 3 during the day
+func weeklyPattern(weeks int) []*float64 {
+	var values []*float64
+	for i := 0; i < weeks*7*24; i++ {
+		v := 1.0
+		if hour := i % 24; hour >= 8 && hour < 18 {
+			v = 3.0
+		}
+		// Small variation so the baselines have a spread
+		v += float64(i%3) * 0.1
+		values = append(values, float(v))
+	}
+	return values
+}
+
+func TestAnalyzeAnomalies(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("flags spikes against both baselines", func(t *testing.T) {
+		values := weeklyPattern(6)
+		spike := 5*7*24 + 2 // 02:00 in the sixth week
+		values[spike] = float(10)
+		m := hourlySeries(from, values...)
+
+		report := AnalyzeAnomalies(m, AnomalyOptions{})
+
+		assert.Equal(t, 3.0, report.Threshold)
+		assert.Greater(t, report.Checked, 0)
+		if assert.Len(t, report.Anomalies, 1) {
+			a := report.Anomalies[0]
+			assert.Equal(t, from.Add(time.Duration(spike)*time.Hour), a.Time)
+			assert.Equal(t, 10.0, a.Value)
+			assert.Equal(t, "high", a.Direction)
+			assert.NotNil(t, a.RollingScore)
+			assert.NotNil(t, a.SeasonalScore)
+			assert.InDelta(t, 1.1, *a.Expected, 0.11)
+			assert.GreaterOrEqual(t, a.Score, 3.0)
+		}
+	})
+
+	t.Run("does not flag regular daily pattern", func(t *testing.T) {
+		m := hourlySeries(from, weeklyPattern(6)...)
+
+		report := AnalyzeAnomalies(m, AnomalyOptions{})
+
+		assert.Empty(t, report.Anomalies)
+	})
+
+	t.Run("flags drops unless high only", func(t *testing.T) {
+		values := weeklyPattern(6)
+		drop := 5*7*24 + 12 // 12:00 in the sixth week
+		values[drop] = float(-5)
+		m := hourlySeries(from, values...)
+
+		report := AnalyzeAnomalies(m, AnomalyOptions{})
+		if assert.Len(t, report.Anomalies, 1) {
+			assert.Equal(t, "low", report.Anomalies[0].Direction)
+		}
+
+		report = AnalyzeAnomalies(m, AnomalyOptions{HighOnly: true})
+		assert.Empty(t, report.Anomalies)
+	})
+
+	t.Run("only flags intervals since", func(t *testing.T) {
+		values := weeklyPattern(6)
+		values[5*7*24+2] = float(10)
+		m := hourlySeries(from, values...)
+
+		report := AnalyzeAnomalies(m, AnomalyOptions{Since: from.AddDate(0, 0, 5*7+1)})
+
+		assert.Empty(t, report.Anomalies)
+	})
+
+	t.Run("flags jumps after constant history", func(t *testing.T) {
+		values := make([]*float64, 10)
+		for i := range values {
+			values[i] = float(2)
+		}
+		values = append(values, float(3))
+		m := hourlySeries(from, values...)
+
+		report := AnalyzeAnomalies(m, AnomalyOptions{Window: 10 * time.Hour})
+
+		if assert.Len(t, report.Anomalies, 1) {
+			assert.Nil(t, report.Anomalies[0].SeasonalScore)
+			assert.InDelta(t, 50, report.Anomalies[0].Score, 1e-6)
+		}
+	})
+}
+
+func TestGetAnomalies(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	var query map[string][]string
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/hour",
+		func(req *http.Request) (*http.Response, error) {
+			query = req.URL.Query()
+			return httpmock.NewJsonResponse(200, MeasurementsWrapper{ID: 1, Resolution: "hour"})
+		})
+
+	report, err := GetAnomalies(c, 1, Hour, from, to, AnomalyOptions{SeasonalWeeks: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SeriesID)
+	assert.Equal(t, []string{"2024-02-16T00:00:00.000Z"}, query["from"])
+}
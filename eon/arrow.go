@@ -0,0 +1,104 @@
+package eon
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// arrowSchema returns the Arrow schema of a row type, with a column per field named like the
+// JSON field. Timestamps are stored in milliseconds and pointer values are nullable.
+func arrowSchema(t reflect.Type) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		field := arrow.Field{Name: strings.Split(f.Tag.Get("json"), ",")[0]}
+
+		switch f.Type {
+		case reflect.TypeOf(""):
+			field.Type = arrow.BinaryTypes.String
+		case reflect.TypeOf(int64(0)):
+			field.Type = arrow.PrimitiveTypes.Int64
+		case reflect.TypeOf(time.Time{}):
+			field.Type = arrow.FixedWidthTypes.Timestamp_ms
+		case reflect.TypeOf((*float64)(nil)):
+			field.Type = arrow.PrimitiveTypes.Float64
+			field.Nullable = true
+		default:
+			return nil, fmt.Errorf("unsupported column type %s of %s", f.Type, f.Name)
+		}
+		fields = append(fields, field)
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// WriteArrow writes rows (MeasurementRow or CostRow) as a single Arrow IPC file
+//
+// Example:
+//
+//	rows := eon.MeasurementRows(measurements, meta)
+//	err := eon.WriteArrow(file, rows)
+func WriteArrow[T MeasurementRow | CostRow](w io.Writer, rows []T) error {
+	schema, err := arrowSchema(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i := range schema.NumFields() {
+			field := v.Field(i)
+			switch b := builder.Field(i).(type) {
+			case *array.StringBuilder:
+				b.Append(field.String())
+			case *array.Int64Builder:
+				b.Append(field.Int())
+			case *array.TimestampBuilder:
+				b.Append(arrow.Timestamp(field.Interface().(time.Time).UnixMilli()))
+			case *array.Float64Builder:
+				if field.IsNil() {
+					b.AppendNull()
+				} else {
+					b.Append(field.Elem().Float())
+				}
+			}
+		}
+	}
+
+	record := builder.NewRecordBatch()
+	defer record.Release()
+
+	writer, err := ipc.NewFileWriter(w, ipc.WithSchema(schema))
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(record); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// WriteMeasurementsArrow writes measurement rows below dir as Arrow IPC files, partitioned like
+// WriteMeasurementsParquet (installation=<id>/month=<YYYY-MM>/measurements.arrow).
+// The paths of the written files are returned.
+func WriteMeasurementsArrow(dir string, rows []MeasurementRow) ([]string, error) {
+	return writePartitions(dir, "measurements.arrow", rows, measurementPartition, WriteArrow[MeasurementRow])
+}
+
+// WriteCostsArrow writes cost rows below dir as Arrow IPC files, partitioned like
+// WriteCostsParquet (installation=<id>/month=<YYYY-MM>/costs.arrow).
+// The paths of the written files are returned.
+func WriteCostsArrow(dir string, rows []CostRow) ([]string, error) {
+	return writePartitions(dir, "costs.arrow", rows, costPartition, WriteArrow[CostRow])
+}
@@ -0,0 +1,98 @@
+package eon
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteArrow(t *testing.T) {
+	value := 1.25
+	rows := []MeasurementRow{
+		{InstallationID: "inst-1", SeriesID: 737605, TimeStamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: &value},
+		{InstallationID: "inst-1", SeriesID: 737605, TimeStamp: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+	}
+
+	var buf bytes.Buffer
+	err := WriteArrow(&buf, rows)
+	assert.NoError(t, err)
+
+	reader, err := ipc.NewFileReader(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	schema := reader.Schema()
+	assert.Equal(t, "installationId", schema.Field(0).Name)
+	assert.Equal(t, arrow.FixedWidthTypes.Timestamp_ms, schema.Field(5).Type)
+	assert.True(t, schema.Field(6).Nullable)
+
+	assert.Equal(t, 1, reader.NumRecords())
+	record, err := reader.RecordBatch(0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), record.NumRows())
+
+	assert.Equal(t, "inst-1", record.Column(0).(*array.String).Value(0))
+	assert.Equal(t, int64(737605), record.Column(1).(*array.Int64).Value(1))
+	timestamps := record.Column(5).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(rows[1].TimeStamp.UnixMilli()), timestamps.Value(1))
+	values := record.Column(6).(*array.Float64)
+	assert.Equal(t, 1.25, values.Value(0))
+	assert.True(t, values.IsNull(1))
+
+	t.Run("expands cost details into columns", func(t *testing.T) {
+		effect := 25.0
+		var buf bytes.Buffer
+		err := WriteArrow(&buf, []CostRow{{Installation: "inst-1", GridEffectVAT: &effect}})
+		assert.NoError(t, err)
+
+		reader, err := ipc.NewFileReader(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+		defer reader.Close()
+
+		indices := reader.Schema().FieldIndices("gridEffectVAT")
+		assert.Len(t, indices, 1)
+		record, err := reader.RecordBatch(0)
+		assert.NoError(t, err)
+		assert.Equal(t, 25.0, record.Column(indices[0]).(*array.Float64).Value(0))
+	})
+}
+
+func TestWriteMeasurementsArrow(t *testing.T) {
+	dir := t.TempDir()
+	rows := []MeasurementRow{
+		{InstallationID: "inst-1", TimeStamp: time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)},
+		{InstallationID: "inst-1", TimeStamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	paths, err := WriteMeasurementsArrow(dir, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "installation=inst-1", "month=2024-01", "measurements.arrow"),
+		filepath.Join(dir, "installation=inst-1", "month=2024-02", "measurements.arrow"),
+	}, paths)
+
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	}
+}
+
+func TestWriteCostsArrow(t *testing.T) {
+	dir := t.TempDir()
+	rows := []CostRow{
+		{Installation: "inst-1", Month: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	paths, err := WriteCostsArrow(dir, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "installation=inst-1", "month=2024-03", "costs.arrow")}, paths)
+}
@@ -1,7 +1,9 @@
 package eon
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -16,15 +18,27 @@ type OAuth2TokenResponse struct {
 	Scope       string `json:"scope"`
 }
 
+// tokenClient returns the client of the token endpoint, which has no /api prefix
+func (c *client) tokenClient() *resty.Client {
+	if c.auth == nil {
+		c.auth = resty.New()
+	}
+	return c.auth
+}
+
 // authenticate fetches an OAuth2 access token using client credentials flow
-func (c *client) authenticate() error {
+func (c *client) authenticate(ctx context.Context) (err error) {
 	var result OAuth2TokenResponse
 
-	// Create a temporary client for token endpoint (no /api prefix)
-	tokenClient := resty.New()
+	ctx, call := c.startCall(ctx, "Token", nil)
+	status := 0
+	defer func() { call.end(ctx, 1, status, err) }()
+
+	c.log().Debug("refreshing access token", slog.String("client_id", c.clientID))
 
 	// Request token using client credentials flow with form data
-	res, err := tokenClient.R().
+	res, err := c.tokenClient().R().
+		SetContext(ctx).
 		SetFormData(map[string]string{
 			"client_id":     c.clientID,
 			"client_secret": c.clientSecret,
@@ -38,6 +52,7 @@ func (c *client) authenticate() error {
 		return fmt.Errorf("failed to authenticate: %w", err)
 	}
 
+	status = res.StatusCode()
 	if res.StatusCode() != http.StatusOK {
 		return fmt.Errorf("authentication failed with status %d: %s", res.StatusCode(), res.String())
 	}
@@ -47,13 +62,26 @@ func (c *client) authenticate() error {
 	// Subtract 60 seconds as safety margin
 	c.tokenExpiry = time.Now().Add(time.Duration(result.ExpiresIn-60) * time.Second)
 
+	c.log().Debug("access token refreshed", slog.Int("status", res.StatusCode()), slog.Duration("duration", res.Time()), slog.Time("expiry", c.tokenExpiry))
+
 	return nil
 }
 
 // GetAccessToken returns a valid access token, authenticating if necessary
 func (c *client) GetAccessToken() (string, error) {
+	return c.token(c.context())
+}
+
+// token returns a valid access token of the root client, authenticating in ctx if necessary.
+// Concurrent callers wait for a single refresh instead of each fetching a token.
+func (c *client) token(ctx context.Context) (string, error) {
+	if c.root != nil {
+		return c.root.token(ctx)
+	}
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 	if c.accessToken == "" || time.Now().After(c.tokenExpiry) {
-		if err := c.authenticate(); err != nil {
+		if err := c.authenticate(ctx); err != nil {
 			return "", err
 		}
 	}
@@ -1,7 +1,10 @@
 package eon
 
 import (
+	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -51,6 +54,43 @@ func TestGetAccessToken(t *testing.T) {
 	})
 }
 
+func TestTokenConcurrency(t *testing.T) {
+	mockResty := resty.New()
+	mockAuth := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	httpmock.ActivateNonDefault(mockAuth.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		resty:        mockResty,
+		auth:         mockAuth,
+	}
+
+	var fetches atomic.Int32
+	httpmock.RegisterResponder("POST", tokenEndpoint,
+		func(req *http.Request) (*http.Response, error) {
+			fetches.Add(1)
+			return httpmock.NewJsonResponse(200, OAuth2TokenResponse{AccessToken: "fake-token", ExpiresIn: 3600})
+		})
+
+	// Derived clients share the token of c, like the requests of the gateway
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := WithContext(c, context.Background()).GetAccessToken()
+			assert.NoError(t, err)
+			assert.Equal(t, "fake-token", token)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
 func TestTokenExpiry(t *testing.T) {
 	t.Run("token expiry is calculated correctly", func(t *testing.T) {
 		c := &client{}
@@ -0,0 +1,334 @@
+package eon
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores response bodies by key. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the unexpired value of a key
+	Get(key string) ([]byte, bool)
+	// Set stores a value for ttl, or without expiry if ttl is zero
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes a key
+	Delete(key string)
+}
+
+// CacheOptions sets how long responses of each endpoint are cached. Zero durations use the defaults,
+// negative durations disable caching of the endpoint.
+type CacheOptions struct {
+	Installations     time.Duration // 24 hours by default
+	MeasurementSeries time.Duration // 24 hours by default
+	Measurements      time.Duration // 15 minutes by default
+	Costs             time.Duration // 6 hours by default
+
+	// Settled is the time after which measurements are no longer expected to change. Measurements
+	// of ranges ending longer ago are cached without expiry. 7 days by default.
+	Settled time.Duration
+}
+
+// withDefaults returns the options with zero durations set to their defaults
+func (o CacheOptions) withDefaults() CacheOptions {
+	defaults := []struct {
+		value    *time.Duration
+		fallback time.Duration
+	}{
+		{&o.Installations, 24 * time.Hour},
+		{&o.MeasurementSeries, 24 * time.Hour},
+		{&o.Measurements, 15 * time.Minute},
+		{&o.Costs, 6 * time.Hour},
+		{&o.Settled, 7 * 24 * time.Hour},
+	}
+	for _, d := range defaults {
+		if *d.value == 0 {
+			*d.value = d.fallback
+		}
+	}
+	return o
+}
+
+// WithCache caches GET responses of the API in cache, keyed by client ID, path and query
+//
+// Example:
+//
+//	client := eon.New(eon.WithCache(eon.NewMemoryCache(1000), eon.CacheOptions{Measurements: time.Hour}))
+func WithCache(cache Cache, opts CacheOptions) Option {
+	return func(c *client) {
+		httpClient := c.resty.GetClient()
+		next := httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		httpClient.Transport = &cacheTransport{next: next, cache: cache, scope: cacheScope(c.clientID), opts: opts.withDefaults(), now: time.Now}
+	}
+}
+
+// cacheModeKey is the context key of the cache mode of requests
+type cacheModeKey struct{}
+
+// WithCacheMode returns a client sharing c's token and cache whose requests use the cache as given
+// by mode, e.g. CacheRefresh to fetch fresh data and update the cache. Clients not created by this
+// package are returned unchanged.
+//
+// Example:
+//
+//	fresh, err := eon.WithCacheMode(client, eon.CacheRefresh).GetInstallations(nil)
+func WithCacheMode(c Client, mode CacheMode) Client {
+	internal, ok := c.(*client)
+	if !ok {
+		return c
+	}
+	return internal.derive(context.WithValue(internal.context(), cacheModeKey{}, mode))
+}
+
+// cacheTransport serves GET requests from a Cache
+type cacheTransport struct {
+	next  http.RoundTripper
+	cache Cache
+	scope string // Key prefix of the client's account, see cacheScope
+	opts  CacheOptions
+	now   func() time.Time
+}
+
+// cacheScope returns the key prefix of the responses of a client ID, so that a cache shared by
+// several accounts never serves the data of one account to another
+func cacheScope(clientID string) string {
+	sum := sha256.Sum256([]byte(clientID))
+	return hex.EncodeToString(sum[:8]) + ":"
+}
+
+// RoundTrip serves a cached response or caches a successful one
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	mode, _ := req.Context().Value(cacheModeKey{}).(CacheMode)
+	ttl, ok := t.ttl(req)
+	if req.Method != http.MethodGet || !ok || mode == CacheBypass {
+		return t.next.RoundTrip(req)
+	}
+
+	key := t.scope + req.URL.Path + "?" + req.URL.Query().Encode()
+	if mode != CacheRefresh {
+		if body, ok := t.cache.Get(key); ok {
+			return &http.Response{
+				Status:        "200 OK",
+				StatusCode:    http.StatusOK,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {"application/json"}, "X-Cache": {"HIT"}},
+				Body:          io.NopCloser(bytes.NewReader(body)),
+				ContentLength: int64(len(body)),
+				Request:       req,
+			}, nil
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.cache.Set(key, body, ttl)
+	return res, nil
+}
+
+// ttl returns how long the response of a request is cached (zero for no expiry),
+// or false if it isn't cached
+func (t *cacheTransport) ttl(req *http.Request) (time.Duration, bool) {
+	path := req.URL.Path
+
+	var ttl time.Duration
+	switch {
+	case strings.HasSuffix(path, "/installations/measurement-series"):
+		ttl = t.opts.MeasurementSeries
+	case strings.HasSuffix(path, "/installations"):
+		ttl = t.opts.Installations
+	case strings.Contains(path, "/costs/"):
+		ttl = t.opts.Costs
+	case strings.Contains(path, "/measurements/"):
+		ttl = t.opts.Measurements
+
+		// Settled past ranges don't change anymore
+		if to, err := time.Parse("2006-01-02T15:04:05.000Z", req.URL.Query().Get("to")); err == nil && t.opts.Settled > 0 && to.Before(t.now().Add(-t.opts.Settled)) {
+			return 0, ttl >= 0
+		}
+	default:
+		return 0, false
+	}
+	return ttl, ttl > 0
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entries
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // Most recently used first
+}
+
+// memoryEntry is an entry of a MemoryCache
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // Zero for no expiry
+}
+
+// NewMemoryCache returns an in-memory LRU cache holding at most size entries (1000 if not positive)
+func NewMemoryCache(size int) *MemoryCache {
+	if size <= 0 {
+		size = 1000
+	}
+	return &MemoryCache{size: size, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get returns the unexpired value of a key
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores a value, evicting the least recently used entry if the cache is full
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(entry)
+
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Delete removes a key
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+		delete(m.entries, key)
+	}
+}
+
+// DiskCache is a Cache storing each entry as a file in a directory, so it survives restarts.
+// Failures to read or write entries are treated as cache misses.
+type DiskCache struct {
+	dir string
+}
+
+// diskEntry is the file format of a DiskCache entry
+type diskEntry struct {
+	Expires time.Time `json:"expires"` // Zero for no expiry
+	Value   []byte    `json:"value"`
+}
+
+// NewDiskCache returns a cache storing entries in dir, creating it if needed
+//
+// Example:
+//
+//	cache, err := eon.NewDiskCache(filepath.Join(os.TempDir(), "eon-cache"))
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// path returns the file of a key
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the unexpired value of a key
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if !entry.Expires.IsZero() && time.Now().After(entry.Expires) {
+		d.Delete(key)
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Set writes a value atomically
+func (d *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	entry := diskEntry{Value: value}
+	if ttl > 0 {
+		entry.Expires = time.Now().Add(ttl)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(d.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), d.path(key))
+}
+
+// Delete removes a key
+func (d *DiskCache) Delete(key string) {
+	_ = os.Remove(d.path(key))
+}
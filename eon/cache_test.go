@@ -0,0 +1,229 @@
+package eon
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("evicts the least recently used entry", func(t *testing.T) {
+		cache := NewMemoryCache(2)
+		cache.Set("a", []byte("1"), 0)
+		cache.Set("b", []byte("2"), 0)
+		_, _ = cache.Get("a")
+		cache.Set("c", []byte("3"), 0)
+
+		_, ok := cache.Get("b")
+		assert.False(t, ok)
+		value, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, "1", string(value))
+		_, ok = cache.Get("c")
+		assert.True(t, ok)
+	})
+
+	t.Run("expires entries", func(t *testing.T) {
+		cache := NewMemoryCache(0)
+		cache.Set("a", []byte("1"), time.Nanosecond)
+		time.Sleep(time.Millisecond)
+
+		_, ok := cache.Get("a")
+		assert.False(t, ok)
+	})
+
+	t.Run("deletes entries", func(t *testing.T) {
+		cache := NewMemoryCache(0)
+		cache.Set("a", []byte("1"), time.Hour)
+		cache.Delete("a")
+
+		_, ok := cache.Get("a")
+		assert.False(t, ok)
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache, err := NewDiskCache(dir)
+	assert.NoError(t, err)
+
+	t.Run("round trips entries", func(t *testing.T) {
+		cache.Set("/installations?", []byte(`{"installations":[]}`), time.Hour)
+
+		value, ok := cache.Get("/installations?")
+		assert.True(t, ok)
+		assert.Equal(t, `{"installations":[]}`, string(value))
+
+		// Survives a new cache on the same directory
+		reopened, err := NewDiskCache(dir)
+		assert.NoError(t, err)
+		_, ok = reopened.Get("/installations?")
+		assert.True(t, ok)
+	})
+
+	t.Run("expires entries", func(t *testing.T) {
+		cache.Set("expired", []byte("1"), time.Nanosecond)
+		time.Sleep(time.Millisecond)
+
+		_, ok := cache.Get("expired")
+		assert.False(t, ok)
+	})
+
+	t.Run("treats corrupt entries as misses", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(cache.path("corrupt"), []byte("not json"), 0o600))
+
+		_, ok := cache.Get("corrupt")
+		assert.False(t, ok)
+	})
+}
+
+func TestWithCache(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+	cache := NewMemoryCache(0)
+	WithCache(cache, CacheOptions{Costs: -1})(c)
+
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{Installations: []InstallationDto{{ID: "inst-1"}}}))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{"energyClass": "El", "installation": "inst-1"}))
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, hourlySeries(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), float(1))))
+	httpmock.RegisterResponder("GET", "/measurements/404/resolution/hour",
+		httpmock.NewStringResponder(404, "not found"))
+
+	t.Run("serves repeated requests from the cache", func(t *testing.T) {
+		httpmock.ZeroCallCounters()
+
+		first, err := c.GetInstallations(nil)
+		assert.NoError(t, err)
+		second, err := c.GetInstallations(nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("keys on the query", func(t *testing.T) {
+		httpmock.ZeroCallCounters()
+
+		_, err := c.GetInstallations([]string{"inst-1"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("keys on the client", func(t *testing.T) {
+		otherResty := resty.New()
+		httpmock.ActivateNonDefault(otherResty.GetClient())
+		other := &client{
+			clientID:    "other-client-id",
+			accessToken: "other-token",
+			tokenExpiry: time.Now().Add(1 * time.Hour),
+			resty:       otherResty,
+		}
+		WithCache(cache, CacheOptions{})(other)
+		httpmock.ZeroCallCounters()
+
+		_, err := c.GetInstallations(nil)
+		assert.NoError(t, err)
+		_, err = other.GetInstallations(nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("refreshes the cache", func(t *testing.T) {
+		httpmock.ZeroCallCounters()
+
+		_, err := WithCacheMode(c, CacheRefresh).GetInstallations(nil)
+		assert.NoError(t, err)
+		_, err = c.GetInstallations(nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("bypasses the cache", func(t *testing.T) {
+		httpmock.ZeroCallCounters()
+
+		bypass := WithCacheMode(c, CacheBypass)
+		_, err := bypass.GetInstallations(nil)
+		assert.NoError(t, err)
+		_, err = bypass.GetInstallations(nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("skips disabled endpoints", func(t *testing.T) {
+		httpmock.ZeroCallCounters()
+
+		_, err := c.GetCosts("inst-1", nil, nil)
+		assert.NoError(t, err)
+		_, err = c.GetCosts("inst-1", nil, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("doesn't cache errors", func(t *testing.T) {
+		httpmock.ZeroCallCounters()
+
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := c.GetMeasurements(404, Hour, from, from.Add(time.Hour), false)
+		assert.Error(t, err)
+		_, err = c.GetMeasurements(404, Hour, from, from.Add(time.Hour), false)
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, httpmock.GetTotalCallCount())
+	})
+}
+
+func TestCacheTransportTTL(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	transport := &cacheTransport{opts: CacheOptions{}.withDefaults(), now: func() time.Time { return now }}
+
+	ttl := func(path string) (time.Duration, bool) {
+		req, err := http.NewRequest(http.MethodGet, apiBaseURL+path, nil)
+		assert.NoError(t, err)
+		return transport.ttl(req)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		ttl    time.Duration
+		cached bool
+	}{
+		{"installations", "/installations", 24 * time.Hour, true},
+		{"measurement series", "/installations/measurement-series", 24 * time.Hour, true},
+		{"costs", "/costs/inst-1", 6 * time.Hour, true},
+		{"recent measurements", "/measurements/1/resolution/hour?from=2024-06-14T00:00:00.000Z&to=2024-06-15T00:00:00.000Z", 15 * time.Minute, true},
+		{"settled measurements", "/measurements/1/resolution/hour?from=2024-01-01T00:00:00.000Z&to=2024-02-01T00:00:00.000Z", 0, true},
+		{"other endpoints", "/isalive", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, cached := ttl(tt.path)
+			assert.Equal(t, tt.ttl, ttl)
+			assert.Equal(t, tt.cached, cached)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ComparisonBucket pairs a bucket of the compared period with the aligned bucket of the reference period.
+// Time or AgainstTime is nil when a bucket has no counterpart, e.g. for periods of different lengths.
+type ComparisonBucket struct {
+	Time         *time.Time `json:"time"`
+	AgainstTime  *time.Time `json:"againstTime"`
+	Value        *float64   `json:"value"`
+	Against      *float64   `json:"against"`
+	Delta        *float64   `json:"delta"`
+	DeltaPercent *float64   `json:"deltaPercent"`
+}
+
+// Comparison holds the per-bucket and total differences between two periods of a series
+type Comparison struct {
+	SeriesID     int                `json:"seriesId"`
+	Resolution   string             `json:"resolution"`
+	Alignment    Alignment          `json:"alignment"`
+	Period       Interval           `json:"period"`
+	Against      Interval           `json:"against"`
+	Buckets      []ComparisonBucket `json:"buckets"`
+	Total        float64            `json:"total"`
+	AgainstTotal float64            `json:"againstTotal"`
+	Delta        float64            `json:"delta"`
+	DeltaPercent *float64           `json:"deltaPercent"`
+}
+
+// ParsePeriod parses a year (2024), month (2024-03) or day (2024-03-05) into an interval in loc (UTC if nil)
+func ParsePeriod(s string, loc *time.Location) (Interval, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	layouts := []struct {
+		layout string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006", 1, 0, 0},
+		{"2006-01", 0, 1, 0},
+		{time.DateOnly, 0, 0, 1},
+	}
+
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l.layout, s, loc); err == nil {
+			return Interval{From: t, To: t.AddDate(l.years, l.months, l.days)}, nil
+		}
+	}
+
+	return Interval{}, fmt.Errorf("invalid period: %q (expected YYYY, YYYY-MM or YYYY-MM-DD)", s)
+}
+
+// GetComparison fetches a series for two periods and compares them bucket by bucket.
+// The periods are interpreted in loc (UTC if nil), which also drives the alignment.
+//
+// Example:
+//
+//	period, _ := eon.ParsePeriod("2024-03", loc)
+//	against, _ := eon.ParsePeriod("2023-03", loc)
+//	comparison, err := eon.GetComparison(client, 737605, eon.Day, period, against, eon.AlignWeekday, loc)
+func GetComparison(c Client, seriesID int, resolution Resolution, period, against Interval, alignment Alignment, loc *time.Location) (Comparison, error) {
+	current, err := measurementsIn(c, seriesID, resolution, period)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	// Fetch a few extra days for AlignWeekday to shift the reference period
+	fetch := against
+	if alignment == AlignWeekday {
+		fetch = Interval{From: against.From.AddDate(0, 0, -3), To: against.To.AddDate(0, 0, 3)}
+	}
+
+	reference, err := measurementsIn(c, seriesID, resolution, fetch)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	comparison, err := Compare(current, reference, period, against, alignment, loc)
+	if err != nil {
+		return Comparison{}, err
+	}
+	comparison.SeriesID = seriesID
+	comparison.Resolution = string(resolution)
+	return comparison, nil
+}
+
+// measurementsIn fetches the measurements of a series within the half-open interval
+func measurementsIn(c Client, seriesID int, resolution Resolution, interval Interval) (MeasurementsWrapper, error) {
+	m, err := c.GetMeasurements(seriesID, resolution, interval.From.UTC(), interval.To.Add(-time.Second).UTC(), false)
+	if err != nil {
+		return MeasurementsWrapper{}, err
+	}
+	return m.Between(interval.From, interval.To), nil
+}
+
+// Between returns the measurements with timestamps within [from, to)
+func (m MeasurementsWrapper) Between(from, to time.Time) MeasurementsWrapper {
+	result := MeasurementsWrapper{ID: m.ID, Resolution: m.Resolution, Measurements: []MeasurementDto{}}
+	for _, measurement := range m.Measurements {
+		ts := measurement.TimeStamp.Time
+		if !ts.Before(from) && ts.Before(to) {
+			result.Measurements = append(result.Measurements, measurement)
+		}
+	}
+	return result
+}
+
+// Compare aligns the measurements of two periods and returns per-bucket deltas and percentages.
+//
+// With AlignCalendar, buckets are matched by month, day of month and local time of day relative
+// to the start of each period (2024-03-05 10:00 with 2023-03-05 10:00). With AlignWeekday, the
+// reference period is shifted by up to three days so that both periods start on the same weekday,
+// and buckets are matched by day offset and local time of day.
+//
+// Matching on local wall-clock time in loc (UTC if nil) keeps buckets aligned across DST changes.
+// Buckets without a counterpart (different lengths, leap days, DST hours) are kept with nil deltas.
+// The values of the hour repeated when DST ends are summed into one bucket, dated by the first.
+func Compare(current, against MeasurementsWrapper, period, againstPeriod Interval, alignment Alignment, loc *time.Location) (Comparison, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	type key struct {
+		months, days, hour, minute int
+	}
+
+	var keyOf func(t time.Time, start time.Time) key
+	againstStart := againstPeriod.From.In(loc)
+	againstEnd := againstPeriod.To.In(loc)
+	switch alignment {
+	case AlignCalendar:
+		keyOf = func(t time.Time, start time.Time) key {
+			t, start = t.In(loc), start.In(loc)
+			months := (t.Year()-start.Year())*12 + int(t.Month()-start.Month())
+			return key{months, t.Day(), t.Hour(), t.Minute()}
+		}
+	case AlignWeekday:
+		shift := (int(period.From.In(loc).Weekday()) - int(againstStart.Weekday()) + 7) % 7
+		if shift > 3 {
+			shift -= 7
+		}
+		againstStart = againstStart.AddDate(0, 0, shift)
+		againstEnd = againstEnd.AddDate(0, 0, shift)
+		keyOf = func(t time.Time, start time.Time) key {
+			t, start = t.In(loc), start.In(loc)
+			return key{0, calendarDays(start, t), t.Hour(), t.Minute()}
+		}
+	default:
+		return Comparison{}, fmt.Errorf("invalid alignment: %q", alignment)
+	}
+
+	comparison := Comparison{
+		SeriesID:   current.ID,
+		Resolution: current.Resolution,
+		Alignment:  alignment,
+		Period:     period,
+		Against:    againstPeriod,
+		Buckets:    []ComparisonBucket{},
+	}
+
+	buckets := map[key]*ComparisonBucket{}
+	var keys []key
+	bucket := func(k key) *ComparisonBucket {
+		b, ok := buckets[k]
+		if !ok {
+			b = &ComparisonBucket{}
+			buckets[k] = b
+			keys = append(keys, k)
+		}
+		return b
+	}
+
+	for _, m := range current.Measurements {
+		ts := m.TimeStamp.Time
+		if ts.Before(period.From) || !ts.Before(period.To) {
+			continue
+		}
+		b := bucket(keyOf(ts, period.From))
+		if b.Time == nil {
+			b.Time = &ts
+		}
+		if m.Value != nil {
+			b.Value = addTo(b.Value, *m.Value)
+			comparison.Total += *m.Value
+		}
+	}
+	for _, m := range against.Measurements {
+		ts := m.TimeStamp.Time
+		if ts.Before(againstStart) || !ts.Before(againstEnd) {
+			continue // Outside the (shifted) reference period
+		}
+		b := bucket(keyOf(ts, againstStart))
+		if b.AgainstTime == nil {
+			b.AgainstTime = &ts
+		}
+		if m.Value != nil {
+			b.Against = addTo(b.Against, *m.Value)
+			comparison.AgainstTotal += *m.Value
+		}
+	}
+
+	// Keys order buckets by their local wall-clock position within the period
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.months != b.months {
+			return a.months < b.months
+		}
+		if a.days != b.days {
+			return a.days < b.days
+		}
+		if a.hour != b.hour {
+			return a.hour < b.hour
+		}
+		return a.minute < b.minute
+	})
+	for _, k := range keys {
+		b := buckets[k]
+		if b.Value != nil && b.Against != nil {
+			delta := *b.Value - *b.Against
+			b.Delta = &delta
+			b.DeltaPercent = percentChange(*b.Against, *b.Value)
+		}
+		comparison.Buckets = append(comparison.Buckets, *b)
+	}
+
+	comparison.Delta = comparison.Total - comparison.AgainstTotal
+	comparison.DeltaPercent = percentChange(comparison.AgainstTotal, comparison.Total)
+
+	return comparison, nil
+}
+
+// calendarDays returns the number of calendar days from the date of a to the date of b
+func calendarDays(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
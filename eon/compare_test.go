@@ -0,0 +1,208 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func dailySeries(from time.Time, values ...float64) MeasurementsWrapper {
+	m := MeasurementsWrapper{ID: 12345, Resolution: "day"}
+	for i, v := range values {
+		m.Measurements = append(m.Measurements, MeasurementDto{
+			TimeStamp: FlexibleTime{Time: from.AddDate(0, 0, i)},
+			Value:     &v,
+		})
+	}
+	return m
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Interval
+	}{
+		{"2024", Interval{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{"2024-02", Interval{From: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		{"2024-02-29", Interval{From: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			interval, err := ParsePeriod(tt.input, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, interval)
+		})
+	}
+
+	_, err := ParsePeriod("March", nil)
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	period, _ := ParsePeriod("2024-02", nil)
+	against, _ := ParsePeriod("2023-02", nil)
+
+	current := dailySeries(period.From, make([]float64, 29)...)    // Leap year
+	reference := dailySeries(against.From, make([]float64, 28)...) // Regular year
+	for i := range current.Measurements {
+		v := float64(i + 1)
+		current.Measurements[i].Value = &v
+	}
+	for i := range reference.Measurements {
+		v := float64(i+1) / 2
+		reference.Measurements[i].Value = &v
+	}
+
+	t.Run("calendar alignment matches day of month", func(t *testing.T) {
+		comparison, err := Compare(current, reference, period, against, AlignCalendar, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, comparison.Buckets, 29)
+
+		first := comparison.Buckets[0]
+		assert.Equal(t, 1.0, *first.Value)
+		assert.Equal(t, 0.5, *first.Against)
+		assert.Equal(t, 0.5, *first.Delta)
+		assert.Equal(t, 100.0, *first.DeltaPercent)
+
+		// February 29th has no counterpart
+		leap := comparison.Buckets[28]
+		assert.Equal(t, 29.0, *leap.Value)
+		assert.Nil(t, leap.AgainstTime)
+		assert.Nil(t, leap.Delta)
+
+		assert.Equal(t, 435.0, comparison.Total)
+		assert.Equal(t, 203.0, comparison.AgainstTotal)
+		assert.Equal(t, 232.0, comparison.Delta)
+	})
+
+	t.Run("weekday alignment shifts the reference period", func(t *testing.T) {
+		// 2024-02-01 is a Thursday, 2023-02-01 a Wednesday
+		reference := dailySeries(against.From.AddDate(0, 0, -3), make([]float64, 34)...)
+		for i := range reference.Measurements {
+			v := float64(reference.Measurements[i].TimeStamp.Day())
+			reference.Measurements[i].Value = &v
+		}
+
+		comparison, err := Compare(current, reference, period, against, AlignWeekday, nil)
+
+		assert.NoError(t, err)
+		first := comparison.Buckets[0]
+		assert.Equal(t, time.Date(2023, 2, 2, 0, 0, 0, 0, time.UTC), *first.AgainstTime)
+		assert.Equal(t, first.Time.Weekday(), first.AgainstTime.Weekday())
+		assert.Len(t, comparison.Buckets, 29)
+		assert.Equal(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), *comparison.Buckets[27].AgainstTime)
+	})
+
+	t.Run("aligns local hours across DST", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Stockholm")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+
+		// Sunday 2024-03-31 has 23 local hours, the Sunday before has 24
+		period, _ := ParsePeriod("2024-03-31", loc)
+		against, _ := ParsePeriod("2024-03-24", loc)
+
+		var currentValues, referenceValues []*float64
+		for i := 0; i < 23; i++ {
+			currentValues = append(currentValues, float(2))
+		}
+		for i := 0; i < 24; i++ {
+			referenceValues = append(referenceValues, float(1))
+		}
+
+		comparison, err := Compare(hourlySeries(period.From, currentValues...), hourlySeries(against.From, referenceValues...), period, against, AlignWeekday, loc)
+
+		assert.NoError(t, err)
+		assert.Len(t, comparison.Buckets, 24)
+
+		// Local 02:00 doesn't exist on the day DST starts
+		assert.Nil(t, comparison.Buckets[2].Time)
+		assert.Equal(t, 2, comparison.Buckets[2].AgainstTime.In(loc).Hour())
+		assert.Nil(t, comparison.Buckets[2].Delta)
+
+		assert.Equal(t, 3, comparison.Buckets[3].Time.In(loc).Hour())
+		assert.Equal(t, 3, comparison.Buckets[3].AgainstTime.In(loc).Hour())
+		assert.Equal(t, 1.0, *comparison.Buckets[3].Delta)
+	})
+
+	t.Run("sums the hour repeated when DST ends", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Stockholm")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+
+		// Sunday 2024-10-27 has 25 local hours, the Sunday before has 24
+		period, _ := ParsePeriod("2024-10-27", loc)
+		against, _ := ParsePeriod("2024-10-20", loc)
+
+		var currentValues, referenceValues []*float64
+		for i := 0; i < 25; i++ {
+			currentValues = append(currentValues, float(2))
+		}
+		for i := 0; i < 24; i++ {
+			referenceValues = append(referenceValues, float(1))
+		}
+
+		comparison, err := Compare(hourlySeries(period.From, currentValues...), hourlySeries(against.From, referenceValues...), period, against, AlignWeekday, loc)
+
+		assert.NoError(t, err)
+		assert.Len(t, comparison.Buckets, 24)
+		assert.Equal(t, 4.0, *comparison.Buckets[2].Value)
+		assert.Equal(t, 3.0, *comparison.Buckets[2].Delta)
+		assert.Equal(t, period.From.Add(2*time.Hour), *comparison.Buckets[2].Time)
+
+		sum := 0.0
+		for _, b := range comparison.Buckets {
+			sum += *b.Value
+		}
+		assert.Equal(t, comparison.Total, sum)
+	})
+
+	t.Run("rejects invalid alignment", func(t *testing.T) {
+		_, err := Compare(current, reference, period, against, Alignment("lunar"), nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetComparison(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	period, _ := ParsePeriod("2024", nil)
+	against, _ := ParsePeriod("2023", nil)
+
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/month",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         12345,
+			Resolution: "month",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}, Value: float(10)},
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Value: float(12)},
+			},
+		}))
+
+	comparison, err := GetComparison(c, 12345, Month, period, against, AlignCalendar, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, comparison.Buckets, 1)
+	assert.Equal(t, 12.0, comparison.Total)
+	assert.Equal(t, 10.0, comparison.AgainstTotal)
+	assert.InDelta(t, 20.0, *comparison.DeltaPercent, 1e-9)
+}
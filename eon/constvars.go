@@ -4,6 +4,24 @@ import "time"
 
 type Resolution string
 
+type Bucket string
+
+type Aggregation string
+
+type FillStrategy string
+
+type SummaryPeriod string
+
+type PortfolioGroupBy string
+
+type Alignment string
+
+type RuleType string
+
+type EnergyCarrier string
+
+type CacheMode string
+
 const (
 	// Eon API endpoints
 	tokenEndpoint = "https://navigator-api.eon.se/connect/token"
@@ -22,3 +40,84 @@ const (
 	MaximumDayRequestLeap  int           = 730
 	MaximumRequestDuration time.Duration = time.Hour * 24 * 730
 )
+
+// Bucket sizes supported by Resample.
+// Any Go duration string (e.g. "6h", "48h") may also be used as a custom bucket size.
+const (
+	BucketHour    Bucket = "hour"
+	BucketDay     Bucket = "day"
+	BucketWeek    Bucket = "week"    // Calendar weeks starting on Sunday
+	BucketISOWeek Bucket = "isoweek" // ISO 8601 weeks starting on Monday
+	BucketMonth   Bucket = "month"
+	BucketYear    Bucket = "year"
+)
+
+// Aggregations supported by Resample
+const (
+	AggregateSum   Aggregation = "sum"
+	AggregateMean  Aggregation = "mean"
+	AggregateMin   Aggregation = "min"
+	AggregateMax   Aggregation = "max"
+	AggregateCount Aggregation = "count"
+)
+
+// Gap-filling strategies supported by FillGaps
+const (
+	FillNone    FillStrategy = "none"    // Leave missing values nil
+	FillZero    FillStrategy = "zero"    // Replace missing values with zero
+	FillForward FillStrategy = "forward" // Repeat the last known value
+	FillLinear  FillStrategy = "linear"  // Interpolate linearly between known values
+	FillProfile FillStrategy = "profile" // Average of known values on the same weekday and hour
+)
+
+// Periods supported by SummarizeCosts
+const (
+	PeriodMonth   SummaryPeriod = "month"
+	PeriodQuarter SummaryPeriod = "quarter"
+	PeriodYear    SummaryPeriod = "year"
+)
+
+// Installation attributes supported by GetPortfolioReport
+const (
+	GroupByPriceArea PortfolioGroupBy = "priceArea"
+	GroupByGridArea  PortfolioGroupBy = "gridArea"
+	GroupByCity      PortfolioGroupBy = "city"
+	GroupByCategory  PortfolioGroupBy = "category"
+	GroupByBusiness  PortfolioGroupBy = "business"
+)
+
+// Period alignments supported by Compare
+const (
+	AlignCalendar Alignment = "calendar" // Match the same day of month and time of day
+	AlignWeekday  Alignment = "weekday"  // Match the same weekday and time of day
+)
+
+// Rule types supported by Watcher
+const (
+	RuleConsumption RuleType = "consumption" // Hourly consumption above a threshold in kWh
+	RuleMonthlyCost RuleType = "monthlyCost" // Month-to-date cost above a budget
+	RuleStale       RuleType = "stale"       // Series not updated within a maximum age
+)
+
+// Swedish electricity price areas (InstallationDto.PriceArea)
+const (
+	PriceAreaSE1 = "SE1" // Luleå
+	PriceAreaSE2 = "SE2" // Sundsvall
+	PriceAreaSE3 = "SE3" // Stockholm
+	PriceAreaSE4 = "SE4" // Malmö
+)
+
+// Energy carriers distinguished by Emissions, see CarrierOf
+const (
+	CarrierElectricity EnergyCarrier = "electricity"
+	CarrierHeat        EnergyCarrier = "heat" // District heating
+	CarrierCold        EnergyCarrier = "cold" // District cooling
+	CarrierGas         EnergyCarrier = "gas"
+)
+
+// Cache modes of WithCacheMode
+const (
+	CacheDefault CacheMode = "default" // Serve from the cache and store responses
+	CacheRefresh CacheMode = "refresh" // Skip cached responses but store new ones
+	CacheBypass  CacheMode = "bypass"  // Neither read nor write the cache
+)
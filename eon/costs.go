@@ -29,28 +29,22 @@ import (
 //	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
 //	costs, err := client.GetCosts("installation-id", &from, &to)
 func (c *client) GetCosts(installationID string, from, to *time.Time) (interface{}, error) {
-	accessToken, err := c.GetAccessToken()
+	req, err := c.request("GetCosts", map[string]interface{}{AttributeInstallationID: installationID})
 	if err != nil {
 		return nil, err
 	}
 
-	var result interface{}
-
-	path := fmt.Sprintf("/costs/%s", installationID)
-
-	req := c.resty.R().
-		SetAuthToken(accessToken).
-		SetResult(&result)
+	var params getCostsParams
 
 	// Add time range parameters if provided
 	if from != nil {
-		req.SetQueryParam("from", from.Format(time.RFC3339))
+		params.From = from.Format(time.RFC3339)
 	}
 	if to != nil {
-		req.SetQueryParam("to", to.Format(time.RFC3339))
+		params.To = to.Format(time.RFC3339)
 	}
 
-	res, err := req.Get(path)
+	result, res, err := getCosts(req, installationID, params)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,149 @@
+package eon
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default base temperatures in °C for degree-days
+const (
+	DefaultHeatingBase = 17.0
+	DefaultCoolingBase = 22.0
+)
+
+// Temperature is the mean outdoor temperature of a day in °C
+type Temperature struct {
+	Date time.Time `json:"date"`
+	Mean float64   `json:"mean"`
+}
+
+// DegreeDayOptions configures DegreeDays. Zero bases use DefaultHeatingBase and DefaultCoolingBase.
+type DegreeDayOptions struct {
+	HeatingBase float64 // Heating degree-days accrue below this temperature
+	CoolingBase float64 // Cooling degree-days accrue above this temperature
+}
+
+// DegreeDay holds the heating and cooling degree-days of a day
+type DegreeDay struct {
+	Date        time.Time `json:"date"`
+	Temperature float64   `json:"temperature"`
+	Heating     float64   `json:"heating"`
+	Cooling     float64   `json:"cooling"`
+}
+
+// LoadTemperatureFile reads outdoor temperatures from a CSV file with a header row and a date (or time)
+// column followed by a temperature (or temp, mean) column. Dates are YYYY-MM-DD, or timestamps as
+// accepted by LoadPriceFile, in loc (UTC if nil). Several readings of a day, e.g. hourly observations,
+// are averaged into the daily mean.
+//
+// Example:
+//
+//	temperatures, err := eon.LoadTemperatureFile("smhi-stockholm.csv", stockholm)
+func LoadTemperatureFile(path string, loc *time.Location) ([]Temperature, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("failed to read %s: empty file", path)
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	find := func(names ...string) (int, bool) {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	dateColumn, ok := find("date", "time")
+	if !ok {
+		return nil, fmt.Errorf("failed to read %s: missing date column", path)
+	}
+	tempColumn, ok := find("temperature", "temp", "mean")
+	if !ok {
+		return nil, fmt.Errorf("failed to read %s: missing temperature column", path)
+	}
+
+	type day struct {
+		sum   float64
+		count int
+	}
+	days := map[time.Time]*day{}
+	for line, record := range records[1:] {
+		if dateColumn >= len(record) || tempColumn >= len(record) || strings.TrimSpace(record[tempColumn]) == "" {
+			continue // No reading
+		}
+
+		t, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(record[dateColumn]), loc)
+		if err != nil {
+			if t, err = parsePriceTime(record[dateColumn], loc); err != nil {
+				return nil, fmt.Errorf("failed to read %s: line %d: %w", path, line+2, err)
+			}
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(strings.Replace(record[tempColumn], ",", ".", 1)), 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: line %d: invalid temperature: %q", path, line+2, record[tempColumn])
+		}
+
+		t = t.In(loc)
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		d, ok := days[date]
+		if !ok {
+			d = &day{}
+			days[date] = d
+		}
+		d.sum += value
+		d.count++
+	}
+
+	temperatures := make([]Temperature, 0, len(days))
+	for date, d := range days {
+		temperatures = append(temperatures, Temperature{Date: date, Mean: d.sum / float64(d.count)})
+	}
+	sort.Slice(temperatures, func(i, j int) bool { return temperatures[i].Date.Before(temperatures[j].Date) })
+	return temperatures, nil
+}
+
+// DegreeDays computes the heating and cooling degree-days of each day: the number of degrees the
+// mean temperature is below the heating base or above the cooling base
+func DegreeDays(temperatures []Temperature, opts DegreeDayOptions) []DegreeDay {
+	if opts.HeatingBase == 0 {
+		opts.HeatingBase = DefaultHeatingBase
+	}
+	if opts.CoolingBase == 0 {
+		opts.CoolingBase = DefaultCoolingBase
+	}
+
+	days := make([]DegreeDay, len(temperatures))
+	for i, t := range temperatures {
+		days[i] = DegreeDay{
+			Date:        t.Date,
+			Temperature: t.Mean,
+			Heating:     max(opts.HeatingBase-t.Mean, 0),
+			Cooling:     max(t.Mean-opts.CoolingBase, 0),
+		}
+	}
+	return days
+}
@@ -0,0 +1,60 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTemperatureFile(t *testing.T) {
+	stockholm, _ := time.LoadLocation("Europe/Stockholm")
+
+	t.Run("reads daily means", func(t *testing.T) {
+		path := writePriceFile(t, "temperatures.csv", "date,temperature\n2024-01-02,-3.5\n2024-01-01,\"-1,5\"\n2024-01-03,\n")
+
+		temperatures, err := LoadTemperatureFile(path, stockholm)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Temperature{
+			{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, stockholm), Mean: -1.5},
+			{Date: time.Date(2024, 1, 2, 0, 0, 0, 0, stockholm), Mean: -3.5},
+		}, temperatures)
+	})
+
+	t.Run("averages hourly readings", func(t *testing.T) {
+		path := writePriceFile(t, "hourly.csv", "time,temp\n2024-01-01 00:00,2\n2024-01-01 12:00,6\n2023-12-31T23:00:00Z,4\n")
+
+		temperatures, err := LoadTemperatureFile(path, stockholm)
+
+		assert.NoError(t, err)
+		assert.Len(t, temperatures, 1)
+		assert.Equal(t, 4.0, temperatures[0].Mean)
+	})
+
+	t.Run("requires a temperature column", func(t *testing.T) {
+		path := writePriceFile(t, "invalid.csv", "date,value\n2024-01-01,2\n")
+
+		_, err := LoadTemperatureFile(path, nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDegreeDays(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	temperatures := []Temperature{{Date: date, Mean: -3}, {Date: date, Mean: 25}, {Date: date, Mean: 19}}
+
+	days := DegreeDays(temperatures, DegreeDayOptions{})
+
+	assert.Equal(t, DegreeDay{Date: date, Temperature: -3, Heating: 20}, days[0])
+	assert.Equal(t, 3.0, days[1].Cooling)
+	assert.Equal(t, DegreeDay{Date: date, Temperature: 19}, days[2])
+
+	t.Run("uses configured bases", func(t *testing.T) {
+		days := DegreeDays(temperatures, DegreeDayOptions{HeatingBase: 20, CoolingBase: 18})
+
+		assert.Equal(t, 1.0, days[2].Heating)
+		assert.Equal(t, 1.0, days[2].Cooling)
+	})
+}
@@ -0,0 +1,306 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EmissionFactorSource provides emission factors in kgCO2e per kWh
+type EmissionFactorSource interface {
+	// Factor returns the factor of an energy carrier in a price area over [from, to),
+	// or false if none is known
+	Factor(carrier EnergyCarrier, area string, from, to time.Time) (float64, bool)
+}
+
+// StaticEmissionFactors holds constant factors in kgCO2e per kWh by carrier and price area.
+// The area "*" applies to areas without a factor of their own.
+//
+// Example:
+//
+//	factors := eon.StaticEmissionFactors{
+//	    eon.CarrierElectricity: {"SE1": 0.02, "SE4": 0.06, "*": 0.04},
+//	    eon.CarrierHeat:        {"*": 0.05},
+//	}
+type StaticEmissionFactors map[EnergyCarrier]map[string]float64
+
+// Factor returns the factor of the carrier in the area, or its default
+func (f StaticEmissionFactors) Factor(carrier EnergyCarrier, area string, from, to time.Time) (float64, bool) {
+	areas, ok := f[carrier]
+	if !ok {
+		return 0, false
+	}
+	for key, factor := range areas {
+		if normalizePriceArea(key) == normalizePriceArea(area) {
+			return factor, true
+		}
+	}
+	factor, ok := areas["*"]
+	return factor, ok
+}
+
+// EmissionFileOptions configures LoadEmissionFactorFile
+type EmissionFileOptions struct {
+	Location *time.Location       // Time zone of timestamps without offset, UTC if nil
+	Grams    bool                 // Factors are in gCO2e per kWh and are converted to kg
+	Fallback EmissionFactorSource // Factors for other carriers and intervals not covered by the file
+}
+
+// HourlyEmissionFactors holds hourly (or quarter-hourly) electricity emission factors per price area,
+// e.g. the grid carbon intensity. Intervals covered by several factors use their time-weighted average.
+type HourlyEmissionFactors struct {
+	factors  map[string][]areaValue // Sorted by time per price area
+	fallback EmissionFactorSource
+}
+
+// LoadEmissionFactorFile reads hourly electricity emission factors from a .csv or .json file in the
+// formats of LoadPriceFile, with a factor column or field in place of price.
+//
+// Example:
+//
+//	factors, err := eon.LoadEmissionFactorFile("intensity.csv", eon.EmissionFileOptions{Grams: true, Fallback: static})
+func LoadEmissionFactorFile(path string, opts EmissionFileOptions) (*HourlyEmissionFactors, error) {
+	factors, err := readAreaFile(path, "factor", opts.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &HourlyEmissionFactors{factors: map[string][]areaValue{}, fallback: opts.Fallback}
+	for _, factor := range factors {
+		if opts.Grams {
+			factor.Value /= 1000
+		}
+		area := normalizePriceArea(factor.Area)
+		f.factors[area] = append(f.factors[area], factor)
+	}
+	for _, area := range f.factors {
+		sort.SliceStable(area, func(i, j int) bool { return area[i].Time.Before(area[j].Time) })
+	}
+	return f, nil
+}
+
+// Factor returns the average electricity factor over [from, to), or the fallback's factor
+func (f *HourlyEmissionFactors) Factor(carrier EnergyCarrier, area string, from, to time.Time) (float64, bool) {
+	if carrier == CarrierElectricity {
+		factors := f.factors[normalizePriceArea(area)]
+		if factor, ok := averageOver(len(factors), func(i int) (time.Time, float64) { return factors[i].Time, factors[i].Value }, from, to); ok {
+			return factor, true
+		}
+	}
+	if f.fallback == nil {
+		return 0, false
+	}
+	return f.fallback.Factor(carrier, area, from, to)
+}
+
+// CarrierOf maps an InstallationDto.EnergyClass to an energy carrier,
+// recognising English and Swedish names ("El", "Fjärrvärme", "Fjärrkyla", "Gas")
+func CarrierOf(energyClass string) (EnergyCarrier, error) {
+	class := strings.ToLower(strings.TrimSpace(energyClass))
+	switch {
+	case strings.Contains(class, "gas"):
+		return CarrierGas, nil
+	case strings.Contains(class, "cold") || strings.Contains(class, "cool") || strings.Contains(class, "kyla"):
+		return CarrierCold, nil
+	case strings.Contains(class, "heat") || strings.Contains(class, "värme") || strings.Contains(class, "varme"):
+		return CarrierHeat, nil
+	case strings.HasPrefix(class, "el") || strings.Contains(class, "electric") || strings.Contains(class, "power"):
+		return CarrierElectricity, nil
+	default:
+		return "", fmt.Errorf("unknown energy class: %q", energyClass)
+	}
+}
+
+// EmissionInterval holds the emissions of a single interval. Factor and Emissions are nil without a factor.
+type EmissionInterval struct {
+	Time        time.Time `json:"time"`
+	Consumption float64   `json:"consumption"` // kWh
+	Factor      *float64  `json:"factor"`      // kgCO2e per kWh
+	Emissions   *float64  `json:"emissions"`   // kgCO2e
+}
+
+// EmissionReport holds the emissions of a series per interval and in total.
+// Totals only include intervals with a factor.
+type EmissionReport struct {
+	Installation  string             `json:"installation"`
+	SeriesID      int                `json:"seriesId"`
+	Resolution    string             `json:"resolution"`
+	Carrier       EnergyCarrier      `json:"carrier"`
+	PriceArea     string             `json:"priceArea"`
+	Intervals     []EmissionInterval `json:"intervals"`
+	Consumption   float64            `json:"consumption"` // kWh
+	Emissions     float64            `json:"emissions"`   // kgCO2e
+	AverageFactor float64            `json:"averageFactor"`
+	Unfactored    int                `json:"unfactored"` // Intervals without a factor
+}
+
+// CarrierEmissions holds the emissions of all installations of an energy carrier
+type CarrierEmissions struct {
+	Carrier       EnergyCarrier `json:"carrier"`
+	Installations int           `json:"installations"`
+	Consumption   float64       `json:"consumption"` // kWh
+	Emissions     float64       `json:"emissions"`   // kgCO2e
+}
+
+// EmissionSummary holds emissions per installation and per energy carrier.
+// Failures for a single installation are recorded in Errors.
+type EmissionSummary struct {
+	From          time.Time          `json:"from"`
+	To            time.Time          `json:"to"`
+	Carriers      []CarrierEmissions `json:"carriers"`
+	Total         CarrierEmissions   `json:"total"`
+	Installations []EmissionReport   `json:"installations"`
+	Errors        []string           `json:"errors"`
+}
+
+// Emissions multiplies the consumption of each interval with the emission factor of the carrier
+// and price area over the interval
+func Emissions(m MeasurementsWrapper, unit string, carrier EnergyCarrier, area string, factors EmissionFactorSource) (EmissionReport, error) {
+	report := EmissionReport{
+		SeriesID:   m.ID,
+		Resolution: m.Resolution,
+		Carrier:    carrier,
+		PriceArea:  normalizePriceArea(area),
+		Intervals:  []EmissionInterval{},
+	}
+
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		consumption, err := ToKWh(*measurement.Value, unit)
+		if err != nil {
+			return EmissionReport{}, err
+		}
+
+		interval := EmissionInterval{Time: measurement.TimeStamp.Time, Consumption: consumption}
+		end, err := Resolution(m.Resolution).next(interval.Time)
+		if err != nil {
+			return EmissionReport{}, err
+		}
+
+		if factor, ok := factors.Factor(carrier, area, interval.Time, end); ok {
+			emissions := consumption * factor
+			interval.Factor, interval.Emissions = &factor, &emissions
+
+			report.Consumption += consumption
+			report.Emissions += emissions
+		} else {
+			report.Unfactored++
+		}
+		report.Intervals = append(report.Intervals, interval)
+	}
+
+	if report.Consumption != 0 {
+		report.AverageFactor = report.Emissions / report.Consumption
+	}
+	return report, nil
+}
+
+// GetEmissions estimates the emissions of the given installations (all if none) over [from, to].
+// Each installation's carrier is derived from its EnergyClass and its first consumption series
+// is used (see IsConsumptionSeries). Hourly factors require quarter or hour resolution.
+//
+// Example:
+//
+//	summary, err := eon.GetEmissions(client, nil, eon.Hour, from, to, factors)
+func GetEmissions(c Client, installationIDs []string, resolution Resolution, from, to time.Time, factors EmissionFactorSource) (EmissionSummary, error) {
+	installations, err := c.GetInstallations(installationIDs)
+	if err != nil {
+		return EmissionSummary{}, err
+	}
+
+	series, err := c.GetMeasurementSeries()
+	if err != nil {
+		return EmissionSummary{}, err
+	}
+
+	consumptionSeries := map[string]MeasurementSeriesDto{}
+	for _, inst := range series.Installations {
+		for _, ms := range inst.MeasurementSeries {
+			if _, ok := consumptionSeries[inst.ID]; !ok && IsConsumptionSeries(ms) {
+				consumptionSeries[inst.ID] = ms
+			}
+		}
+	}
+
+	var reports []EmissionReport
+	var errs []string
+	for _, inst := range installations.Installations {
+		fail := func(err error) {
+			errs = append(errs, fmt.Sprintf("installation %s: %s", inst.ID, err))
+		}
+
+		carrier, err := CarrierOf(inst.EnergyClass)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		ms, ok := consumptionSeries[inst.ID]
+		if !ok {
+			fail(fmt.Errorf("no consumption series found"))
+			continue
+		}
+
+		measurements, err := c.GetMeasurements(ms.ID, resolution, from, to, false)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		if measurements.Resolution == "" {
+			measurements.Resolution = string(resolution)
+		}
+
+		report, err := Emissions(measurements, ms.Unit, carrier, inst.PriceArea, factors)
+		if err != nil {
+			fail(err)
+			continue
+		}
+		report.Installation = inst.ID
+		if report.Unfactored > 0 {
+			fail(fmt.Errorf("%d intervals without a %s emission factor", report.Unfactored, carrier))
+		}
+		reports = append(reports, report)
+	}
+
+	summary := SummarizeEmissions(reports, from, to)
+	summary.Errors = append(summary.Errors, errs...)
+	return summary, nil
+}
+
+// SummarizeEmissions sums emission reports per energy carrier
+func SummarizeEmissions(reports []EmissionReport, from, to time.Time) EmissionSummary {
+	summary := EmissionSummary{
+		From:          from,
+		To:            to,
+		Carriers:      []CarrierEmissions{},
+		Total:         CarrierEmissions{Carrier: "total"},
+		Installations: reports,
+		Errors:        []string{},
+	}
+	if summary.Installations == nil {
+		summary.Installations = []EmissionReport{}
+	}
+
+	carriers := map[EnergyCarrier]*CarrierEmissions{}
+	for _, r := range reports {
+		c, ok := carriers[r.Carrier]
+		if !ok {
+			c = &CarrierEmissions{Carrier: r.Carrier}
+			carriers[r.Carrier] = c
+		}
+		for _, target := range []*CarrierEmissions{c, &summary.Total} {
+			target.Installations++
+			target.Consumption += r.Consumption
+			target.Emissions += r.Emissions
+		}
+	}
+
+	for _, c := range carriers {
+		summary.Carriers = append(summary.Carriers, *c)
+	}
+	sort.Slice(summary.Carriers, func(i, j int) bool { return summary.Carriers[i].Carrier < summary.Carriers[j].Carrier })
+
+	return summary
+}
@@ -0,0 +1,171 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarrierOf(t *testing.T) {
+	for class, expected := range map[string]EnergyCarrier{
+		"El":          CarrierElectricity,
+		"electricity": CarrierElectricity,
+		"Fjärrvärme":  CarrierHeat,
+		"Heat":        CarrierHeat,
+		"Fjärrkyla":   CarrierCold,
+		"cold":        CarrierCold,
+		"Gas":         CarrierGas,
+		"Biogas":      CarrierGas,
+	} {
+		carrier, err := CarrierOf(class)
+		assert.NoError(t, err, class)
+		assert.Equal(t, expected, carrier, class)
+	}
+
+	_, err := CarrierOf("water")
+	assert.Error(t, err)
+}
+
+func TestStaticEmissionFactors(t *testing.T) {
+	factors := StaticEmissionFactors{
+		CarrierElectricity: {"se1": 0.02, "*": 0.04},
+	}
+
+	factor, ok := factors.Factor(CarrierElectricity, "SE1", time.Time{}, time.Time{})
+	assert.True(t, ok)
+	assert.Equal(t, 0.02, factor)
+
+	factor, ok = factors.Factor(CarrierElectricity, "SE3", time.Time{}, time.Time{})
+	assert.True(t, ok)
+	assert.Equal(t, 0.04, factor)
+
+	_, ok = factors.Factor(CarrierHeat, "SE3", time.Time{}, time.Time{})
+	assert.False(t, ok)
+}
+
+func TestHourlyEmissionFactors(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := writePriceFile(t, "intensity.csv", "time,area,factor\n2024-01-01T00:00:00Z,SE3,30\n2024-01-01T01:00:00Z,SE3,50\n")
+
+	factors, err := LoadEmissionFactorFile(path, EmissionFileOptions{Grams: true, Fallback: StaticEmissionFactors{
+		CarrierElectricity: {"*": 0.1},
+		CarrierHeat:        {"*": 0.05},
+	}})
+	assert.NoError(t, err)
+
+	factor, ok := factors.Factor(CarrierElectricity, "SE3", jan, jan.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, 0.03, factor)
+
+	factor, _ = factors.Factor(CarrierElectricity, "SE3", jan.Add(30*time.Minute), jan.Add(90*time.Minute))
+	assert.InDelta(t, 0.04, factor, 1e-9)
+
+	factor, _ = factors.Factor(CarrierElectricity, "SE3", jan.Add(5*time.Hour), jan.Add(6*time.Hour))
+	assert.Equal(t, 0.1, factor)
+
+	factor, _ = factors.Factor(CarrierHeat, "SE3", jan, jan.Add(time.Hour))
+	assert.Equal(t, 0.05, factor)
+
+	t.Run("reads JSON factors", func(t *testing.T) {
+		path := writePriceFile(t, "intensity.json", `[{"time": "2024-01-01T00:00:00Z", "area": "SE1", "factor": 0.02}]`)
+
+		factors, err := LoadEmissionFactorFile(path, EmissionFileOptions{})
+		assert.NoError(t, err)
+
+		factor, ok := factors.Factor(CarrierElectricity, "1", jan, jan.Add(time.Hour))
+		assert.True(t, ok)
+		assert.Equal(t, 0.02, factor)
+	})
+
+	t.Run("rejects price files", func(t *testing.T) {
+		path := writePriceFile(t, "prices.csv", "time,area,price\n2024-01-01T00:00:00Z,SE3,0.5\n")
+
+		_, err := LoadEmissionFactorFile(path, EmissionFileOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestEmissions(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	factors := StaticEmissionFactors{CarrierElectricity: {"SE3": 0.05}}
+
+	t.Run("computes emissions per interval", func(t *testing.T) {
+		m := hourlySeries(jan, float(1000), nil, float(3000))
+
+		report, err := Emissions(m, "WH", CarrierElectricity, "SE3", factors)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Intervals, 2)
+		assert.InDelta(t, 0.15, *report.Intervals[1].Emissions, 1e-9)
+		assert.Equal(t, 4.0, report.Consumption)
+		assert.InDelta(t, 0.2, report.Emissions, 1e-9)
+		assert.InDelta(t, 0.05, report.AverageFactor, 1e-9)
+	})
+
+	t.Run("counts intervals without factor", func(t *testing.T) {
+		report, err := Emissions(hourlySeries(jan, float(1)), "KWH", CarrierGas, "SE3", factors)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.Unfactored)
+		assert.Nil(t, report.Intervals[0].Emissions)
+		assert.Equal(t, 0.0, report.Emissions)
+	})
+}
+
+func TestGetEmissions(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{
+				{ID: "el-1", EnergyClass: "El", PriceArea: "SE3"},
+				{ID: "el-2", EnergyClass: "El", PriceArea: "SE1"},
+				{ID: "heat-1", EnergyClass: "Fjärrvärme", PriceArea: "SE3"},
+				{ID: "water-1", EnergyClass: "Vatten"},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "el-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 1, Unit: "KWH"}}},
+				{ID: "el-2", MeasurementSeries: []MeasurementSeriesDto{{ID: 2, Unit: "KWH"}}},
+				{ID: "heat-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 3, Unit: "MWH"}}},
+			},
+		}))
+	for _, id := range []string{"1", "2", "3"} {
+		httpmock.RegisterResponder("GET", "/measurements/"+id+"/resolution/month",
+			httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+				Resolution:   "month",
+				Measurements: []MeasurementDto{{TimeStamp: FlexibleTime{Time: jan}, Value: float(100)}},
+			}))
+	}
+
+	factors := StaticEmissionFactors{
+		CarrierElectricity: {"SE1": 0.01, "*": 0.05},
+		CarrierHeat:        {"*": 0.02},
+	}
+	summary, err := GetEmissions(c, nil, Month, jan, jan.AddDate(0, 1, 0), factors)
+
+	assert.NoError(t, err)
+	assert.Len(t, summary.Installations, 3)
+	assert.Equal(t, []string{"installation water-1: unknown energy class: \"Vatten\""}, summary.Errors)
+	assert.Equal(t, []CarrierEmissions{
+		{Carrier: CarrierElectricity, Installations: 2, Consumption: 200, Emissions: 6},
+		{Carrier: CarrierHeat, Installations: 1, Consumption: 100000, Emissions: 2000},
+	}, summary.Carriers)
+	assert.Equal(t, 2006.0, summary.Total.Emissions)
+}
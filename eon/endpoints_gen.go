@@ -0,0 +1,105 @@
+// Code generated by swaggergen from docs/swagger.json. DO NOT EDIT.
+
+package eon
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// getCostsParams are the query parameters of getCosts
+type getCostsParams struct {
+	// Optional start time of timespan. Whole month is considered
+	From string
+	// Optional end time of timespan. Whole month is considered
+	To string
+}
+
+// getCosts calls GET /costs/{id}: Returns an object containing some installation metadata and a
+// list of costs based on the input id, resolution and timespan
+//
+// The result is one of CostsElectricityWrapper, CostsProductionWrapper, CostsHeatWrapper,
+// CostsColdWrapper, CostsGasWrapper, depending on the response.
+func getCosts(req *resty.Request, id string, params getCostsParams) (interface{}, *resty.Response, error) {
+	var result interface{}
+	req.SetResult(&result)
+	req.SetPathParam("id", id)
+	if params.From != "" {
+		req.SetQueryParam("from", params.From)
+	}
+	if params.To != "" {
+		req.SetQueryParam("to", params.To)
+	}
+
+	res, err := req.Get("/costs/{id}")
+	return result, res, err
+}
+
+// getInstallationsMeasurementSeries calls GET /installations/measurement-series: Returns a list of
+// installation identifiers with connected measurement series
+func getInstallationsMeasurementSeries(req *resty.Request) (InstallationsMeasurementsWrapper, *resty.Response, error) {
+	var result InstallationsMeasurementsWrapper
+	req.SetResult(&result)
+
+	res, err := req.Get("/installations/measurement-series")
+	return result, res, err
+}
+
+// getInstallationsParams are the query parameters of getInstallations
+type getInstallationsParams struct {
+	// Optional query parameter input filter of installation identifiers. Useable if not all
+	// installations are desired. Example "?installationFilter=1&installationFilter=2".
+	InstallationFilter []string
+}
+
+// getInstallations calls GET /installations: Returns a list of installations with connected
+// metadata
+func getInstallations(req *resty.Request, params getInstallationsParams) (InstallationsWrapper, *resty.Response, error) {
+	var result InstallationsWrapper
+	req.SetResult(&result)
+	if len(params.InstallationFilter) > 0 {
+		req.SetQueryParamsFromValues(url.Values{"installationFilter": params.InstallationFilter})
+	}
+
+	res, err := req.Get("/installations")
+	return result, res, err
+}
+
+// getMeasurementsResolutionParams are the query parameters of getMeasurementsResolution
+type getMeasurementsResolutionParams struct {
+	// Optional start time of timespan. Works in conjuction with the "to" parameter. Will default to
+	// contract period start if given date is lower. Mandatory in the case of quarter (maximum timespan
+	// of 3 months). Mandatory in the case of hour (maximum timespan of 1 year).
+	From string
+	// Optional end time of timespan. Works in conjuction with the "from" parameter. Will default to
+	// contract period end if given value is higher. Mandatory in the case of quarter (maximum timespan
+	// of 3 months). Mandatory in the case of hour (maximum timespan of 1 year).
+	To string
+	// Whether a series should be returned as a full time span (filling in missing values) for given
+	// resolution or not.
+	IncludeMissing *bool
+}
+
+// getMeasurementsResolution calls GET /measurements/{id}/resolution/{resolution}: Returns an object
+// containing the given id and a list of measurements values based on the input id, resolution and
+// timespan. Data can be fetched as far back as the current year, plus five calendar years back.
+func getMeasurementsResolution(req *resty.Request, id int, resolution string, params getMeasurementsResolutionParams) (MeasurementsWrapper, *resty.Response, error) {
+	var result MeasurementsWrapper
+	req.SetResult(&result)
+	req.SetPathParam("id", fmt.Sprint(id))
+	req.SetPathParam("resolution", resolution)
+	if params.From != "" {
+		req.SetQueryParam("from", params.From)
+	}
+	if params.To != "" {
+		req.SetQueryParam("to", params.To)
+	}
+	if params.IncludeMissing != nil {
+		req.SetQueryParam("includeMissing", fmt.Sprint(*params.IncludeMissing))
+	}
+
+	res, err := req.Get("/measurements/{id}/resolution/{resolution}")
+	return result, res, err
+}
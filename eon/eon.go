@@ -1,7 +1,11 @@
 package eon
 
 import (
+	"context"
+	"log/slog"
+	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -13,21 +17,26 @@ type client struct {
 	clientSecret string
 	accessToken  string
 	tokenExpiry  time.Time
+	tokenMu      sync.Mutex // Guards accessToken and tokenExpiry of a root client
 	resty        *resty.Client
+	auth         *resty.Client // Client of the token endpoint
+	logger       *slog.Logger
+	telemetry    Telemetry
+	ctx          context.Context // Context of requests, see WithContext and WithCacheMode
+	root         *client         // Client holding the token of derived clients
 }
 
+// Option configures a client created by New or NewWithCredentials
+type Option func(*client)
+
 // New creates and returns a new Eon client.
 // Credentials are loaded from environment variables CLIENT_ID and CLIENT_SECRET.
 //
 // Example:
 //
 //	client := eon.New()
-func New() Client {
-	return &client{
-		clientID:     os.Getenv("CLIENT_ID"),
-		clientSecret: os.Getenv("CLIENT_SECRET"),
-		resty:        resty.New().SetBaseURL(apiBaseURL),
-	}
+func New(opts ...Option) Client {
+	return NewWithCredentials(os.Getenv("CLIENT_ID"), os.Getenv("CLIENT_SECRET"), opts...)
 }
 
 // NewWithCredentials creates an Eon client with explicit credentials.
@@ -35,10 +44,96 @@ func New() Client {
 // Example:
 //
 //	client := eon.NewWithCredentials(clientID, clientSecret)
-func NewWithCredentials(clientID, clientSecret string) Client {
-	return &client{
+func NewWithCredentials(clientID, clientSecret string, opts ...Option) Client {
+	c := &client{
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		resty:        resty.New().SetBaseURL(apiBaseURL),
+		auth:         resty.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithRetries retries requests failing with a network error, status 429 or a 5xx status up to count
+// times, waiting about wait before the first retry and backing off exponentially
+//
+// Example:
+//
+//	client := eon.New(eon.WithRetries(3, time.Second))
+func WithRetries(count int, wait time.Duration) Option {
+	return func(c *client) {
+		c.resty.
+			SetRetryCount(count).
+			SetRetryWaitTime(wait).
+			AddRetryCondition(func(res *resty.Response, err error) bool {
+				if err != nil {
+					return true
+				}
+				return res.StatusCode() == http.StatusTooManyRequests || res.StatusCode() >= http.StatusInternalServerError
+			})
+	}
+}
+
+// derive returns a copy of the client sharing its token and HTTP client, with requests using ctx
+func (c *client) derive(ctx context.Context) *client {
+	derived := &client{
+		clientID:     c.clientID,
+		clientSecret: c.clientSecret,
+		resty:        c.resty,
+		auth:         c.auth,
+		logger:       c.logger,
+		telemetry:    c.telemetry,
+		ctx:          ctx,
+		root:         c.root,
+	}
+	if c.root == nil {
+		derived.root = c
+	}
+	return derived
+}
+
+// WithContext returns a client sharing c's token and cache whose requests use ctx, for cancellation
+// and as parent of telemetry spans. Clients not created by this package are returned unchanged.
+//
+// Example:
+//
+//	installations, err := eon.WithContext(client, ctx).GetInstallations(nil)
+func WithContext(c Client, ctx context.Context) Client {
+	internal, ok := c.(*client)
+	if !ok {
+		return c
+	}
+
+	// Keep the cache mode of the client
+	if internal.ctx != nil && ctx.Value(cacheModeKey{}) == nil {
+		if mode, ok := internal.ctx.Value(cacheModeKey{}).(CacheMode); ok {
+			ctx = context.WithValue(ctx, cacheModeKey{}, mode)
+		}
 	}
+	return internal.derive(ctx)
+}
+
+// context returns the context of requests of the client
+func (c *client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// request returns an authenticated request of an endpoint in the client's context, instrumented
+// with the given span attributes
+func (c *client) request(endpoint string, attributes map[string]interface{}) (*resty.Request, error) {
+	ctx, call := c.startCall(c.context(), endpoint, attributes)
+
+	accessToken, err := c.token(ctx)
+	if err != nil {
+		call.end(ctx, 0, 0, err)
+		return nil, err
+	}
+
+	return c.resty.R().SetContext(ctx).SetAuthToken(accessToken), nil
 }
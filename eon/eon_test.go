@@ -39,4 +39,10 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, "my-client-secret", internalClient.clientSecret)
 		assert.Equal(t, apiBaseURL, internalClient.resty.BaseURL)
 	})
+	t.Run("applies options", func(t *testing.T) {
+		applied := false
+		c := NewWithCredentials("my-client-id", "my-client-secret", func(c *client) { applied = true })
+		assert.NotNil(t, c)
+		assert.True(t, applied)
+	})
 }
@@ -0,0 +1,110 @@
+package eon
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MeasurementMetricName is the measurement/metric name used when exporting measurements
+const MeasurementMetricName = "eon_measurement"
+
+var (
+	lineProtocolKeyEscaper  = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+	lineProtocolNameEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+	openMetricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// WriteLineProtocol writes measurements as InfluxDB line protocol.
+// Every point carries its own nanosecond timestamp so history can be backfilled.
+// Measurements without a value are skipped.
+//
+// Example:
+//
+//	meta, _ := eon.GetSeriesMetadata(client, 737605)
+//	err := eon.WriteLineProtocol(os.Stdout, measurements, meta.Tags())
+func WriteLineProtocol(w io.Writer, m MeasurementsWrapper, tags map[string]string) error {
+	bw := bufio.NewWriter(w)
+
+	var prefix strings.Builder
+	prefix.WriteString(lineProtocolNameEscaper.Replace(MeasurementMetricName))
+	for _, key := range sortedKeys(tags) {
+		prefix.WriteString(",")
+		prefix.WriteString(lineProtocolKeyEscaper.Replace(key))
+		prefix.WriteString("=")
+		prefix.WriteString(lineProtocolKeyEscaper.Replace(tags[key]))
+	}
+
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		if _, err := fmt.Fprintf(bw, "%s value=%s %d\n",
+			prefix.String(),
+			strconv.FormatFloat(*measurement.Value, 'f', -1, 64),
+			measurement.TimeStamp.UnixNano(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WriteOpenMetrics writes measurements in the OpenMetrics text exposition format.
+// Every sample carries its own timestamp (in seconds) so history can be backfilled.
+// Measurements without a value are skipped.
+//
+// Example:
+//
+//	meta, _ := eon.GetSeriesMetadata(client, 737605)
+//	err := eon.WriteOpenMetrics(os.Stdout, measurements, meta.Tags())
+func WriteOpenMetrics(w io.Writer, m MeasurementsWrapper, tags map[string]string) error {
+	bw := bufio.NewWriter(w)
+
+	labels := make([]string, 0, len(tags))
+	for _, key := range sortedKeys(tags) {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, key, openMetricsLabelEscaper.Replace(tags[key])))
+	}
+	series := MeasurementMetricName
+	if len(labels) > 0 {
+		series += "{" + strings.Join(labels, ",") + "}"
+	}
+
+	if _, err := fmt.Fprintf(bw, "# HELP %s Eon measurement value.\n# TYPE %s gauge\n", MeasurementMetricName, MeasurementMetricName); err != nil {
+		return err
+	}
+
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		ts := float64(measurement.TimeStamp.UnixMilli()) / 1000
+		if _, err := fmt.Fprintf(bw, "%s %s %s\n",
+			series,
+			strconv.FormatFloat(*measurement.Value, 'f', -1, 64),
+			strconv.FormatFloat(ts, 'f', -1, 64),
+		); err != nil {
+			return err
+		}
+	}
+
+	if _, err := bw.WriteString("# EOF\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// sortedKeys returns the keys of a map in lexical order for deterministic output
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package eon
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteLineProtocol(t *testing.T) {
+	value := 1.5
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	measurements := MeasurementsWrapper{
+		ID:         737605,
+		Resolution: "hour",
+		Measurements: []MeasurementDto{
+			{TimeStamp: FlexibleTime{Time: from}, Value: &value},
+			{TimeStamp: FlexibleTime{Time: from.Add(time.Hour)}, Value: nil},
+		},
+	}
+
+	t.Run("writes tagged points with timestamps", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteLineProtocol(&buf, measurements, map[string]string{
+			"installation_id": "inst-1",
+			"series_type":     "ElectricActive",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "eon_measurement,installation_id=inst-1,series_type=ElectricActive value=1.5 1704067200000000000\n", buf.String())
+	})
+
+	t.Run("escapes tag values", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteLineProtocol(&buf, measurements, map[string]string{"grid_area": "Area 1,North"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `grid_area=Area\ 1\,North `)
+	})
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	value := 2.25
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	measurements := MeasurementsWrapper{
+		Measurements: []MeasurementDto{
+			{TimeStamp: FlexibleTime{Time: from}, Value: &value},
+			{TimeStamp: FlexibleTime{Time: from.Add(time.Hour)}, Value: nil},
+		},
+	}
+
+	t.Run("writes labelled samples and EOF marker", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteOpenMetrics(&buf, measurements, map[string]string{
+			"unit":       "KWH",
+			"price_area": `SE"3`,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# HELP eon_measurement Eon measurement value.\n"+
+			"# TYPE eon_measurement gauge\n"+
+			`eon_measurement{price_area="SE\"3",unit="KWH"} 2.25 1704067200`+"\n"+
+			"# EOF\n", buf.String())
+	})
+
+	t.Run("writes samples without labels", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteOpenMetrics(&buf, measurements, nil)
+
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "eon_measurement 2.25 1704067200\n")
+	})
+}
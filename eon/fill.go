@@ -0,0 +1,159 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FilledMeasurementsWrapper is a continuous measurement series produced by FillGaps
+type FilledMeasurementsWrapper struct {
+	ID           int                    `json:"id"`
+	Resolution   string                 `json:"resolution"`
+	Measurements []FilledMeasurementDto `json:"measurements"`
+}
+
+// FilledMeasurementDto is a measurement that may have been estimated by FillGaps
+type FilledMeasurementDto struct {
+	MeasurementDto
+	Estimated bool `json:"estimated"`
+}
+
+// Wrapper converts the filled series back to a MeasurementsWrapper, dropping the estimated flags
+func (f FilledMeasurementsWrapper) Wrapper() MeasurementsWrapper {
+	m := MeasurementsWrapper{
+		ID:           f.ID,
+		Resolution:   f.Resolution,
+		Measurements: make([]MeasurementDto, 0, len(f.Measurements)),
+	}
+	for _, measurement := range f.Measurements {
+		m.Measurements = append(m.Measurements, measurement.MeasurementDto)
+	}
+	return m
+}
+
+// FillGaps returns a continuous series where every interval between the first and last
+// measurement is present, and missing values are filled according to strategy.
+// Both nil values (as returned with includeMissing) and absent timestamps count as missing.
+// Filled values are flagged as estimated; values that can't be estimated stay nil.
+// The weekday and hour used by FillProfile are taken in loc (UTC if nil).
+//
+// Example:
+//
+//	measurements, _ := client.GetMeasurements(737605, eon.Hour, from, to, true)
+//	filled, err := eon.FillGaps(measurements, eon.FillLinear, nil)
+func FillGaps(m MeasurementsWrapper, strategy FillStrategy, loc *time.Location) (FilledMeasurementsWrapper, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch strategy {
+	case FillNone, FillZero, FillForward, FillLinear, FillProfile:
+	default:
+		return FilledMeasurementsWrapper{}, fmt.Errorf("invalid fill strategy: %q", strategy)
+	}
+
+	resolution := Resolution(m.Resolution)
+	if _, err := resolution.next(time.Time{}); err != nil {
+		return FilledMeasurementsWrapper{}, err
+	}
+
+	result := FilledMeasurementsWrapper{
+		ID:           m.ID,
+		Resolution:   m.Resolution,
+		Measurements: []FilledMeasurementDto{},
+	}
+	if len(m.Measurements) == 0 {
+		return result, nil
+	}
+
+	// Index known values, keeping the first of any duplicate timestamps
+	values := map[int64]*float64{}
+	var timestamps []time.Time
+	for _, measurement := range m.Measurements {
+		key := measurement.TimeStamp.UnixNano()
+		if _, ok := values[key]; ok {
+			continue
+		}
+		values[key] = measurement.Value
+		timestamps = append(timestamps, measurement.TimeStamp.Time)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	last := timestamps[len(timestamps)-1]
+	for ts := timestamps[0]; !ts.After(last); ts, _ = resolution.next(ts) {
+		result.Measurements = append(result.Measurements, FilledMeasurementDto{
+			MeasurementDto: MeasurementDto{TimeStamp: FlexibleTime{Time: ts}, Value: values[ts.UnixNano()]},
+		})
+	}
+
+	points := result.Measurements
+	estimate := func(i int, v float64) {
+		points[i].Value = &v
+		points[i].Estimated = true
+	}
+
+	switch strategy {
+	case FillZero:
+		for i := range points {
+			if points[i].Value == nil {
+				estimate(i, 0)
+			}
+		}
+	case FillForward:
+		var previous *float64
+		for i := range points {
+			if points[i].Value == nil {
+				if previous != nil {
+					estimate(i, *previous)
+				}
+				continue
+			}
+			previous = points[i].Value
+		}
+	case FillLinear:
+		previous := -1
+		for i := range points {
+			if points[i].Value == nil {
+				continue
+			}
+			if previous >= 0 && i-previous > 1 {
+				from, to := *points[previous].Value, *points[i].Value
+				span := points[i].TimeStamp.Sub(points[previous].TimeStamp.Time).Seconds()
+				for j := previous + 1; j < i; j++ {
+					elapsed := points[j].TimeStamp.Sub(points[previous].TimeStamp.Time).Seconds()
+					estimate(j, from+(to-from)*elapsed/span)
+				}
+			}
+			previous = i
+		}
+	case FillProfile:
+		type slot struct {
+			weekday time.Weekday
+			hour    int
+		}
+		sums := map[slot]float64{}
+		counts := map[slot]int{}
+		slotOf := func(t time.Time) slot {
+			t = t.In(loc)
+			return slot{t.Weekday(), t.Hour()}
+		}
+		for _, point := range points {
+			if point.Value != nil {
+				s := slotOf(point.TimeStamp.Time)
+				sums[s] += *point.Value
+				counts[s]++
+			}
+		}
+		for i := range points {
+			if points[i].Value != nil {
+				continue
+			}
+			if s := slotOf(points[i].TimeStamp.Time); counts[s] > 0 {
+				estimate(i, sums[s]/float64(counts[s]))
+			}
+		}
+	}
+
+	return result, nil
+}
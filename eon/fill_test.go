@@ -0,0 +1,104 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func filledValues(f FilledMeasurementsWrapper) ([]*float64, []bool) {
+	var values []*float64
+	var estimated []bool
+	for _, m := range f.Measurements {
+		values = append(values, m.Value)
+		estimated = append(estimated, m.Estimated)
+	}
+	return values, estimated
+}
+
+func TestFillGaps(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 00:00=nil, 01:00=2, 02:00=nil, 03:00 absent, 04:00=8
+	m := hourlySeries(from, nil, float(2), nil, nil, float(8))
+	m.Measurements = append(m.Measurements[:3], m.Measurements[4:]...)
+
+	tests := []struct {
+		strategy  FillStrategy
+		values    []*float64
+		estimated []bool
+	}{
+		{FillNone, []*float64{nil, float(2), nil, nil, float(8)}, []bool{false, false, false, false, false}},
+		{FillZero, []*float64{float(0), float(2), float(0), float(0), float(8)}, []bool{true, false, true, true, false}},
+		{FillForward, []*float64{nil, float(2), float(2), float(2), float(8)}, []bool{false, false, true, true, false}},
+		{FillLinear, []*float64{nil, float(2), float(4), float(6), float(8)}, []bool{false, false, true, true, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			result, err := FillGaps(m, tt.strategy, nil)
+
+			assert.NoError(t, err)
+			assert.Len(t, result.Measurements, 5)
+			assert.Equal(t, from.Add(3*time.Hour), result.Measurements[3].TimeStamp.Time)
+
+			values, estimated := filledValues(result)
+			assert.Equal(t, tt.values, values)
+			assert.Equal(t, tt.estimated, estimated)
+		})
+	}
+
+	t.Run("keeps complete daily series across DST", func(t *testing.T) {
+		stockholm, err := time.LoadLocation("Europe/Stockholm")
+		assert.NoError(t, err)
+		daily := dailySeries(time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), 1, 2, 3, 4, 5)
+
+		result, err := FillGaps(daily, FillLinear, stockholm)
+
+		assert.NoError(t, err)
+		values, estimated := filledValues(result)
+		assert.Equal(t, []*float64{float(1), float(2), float(3), float(4), float(5)}, values)
+		assert.Equal(t, []bool{false, false, false, false, false}, estimated)
+	})
+
+	t.Run("profile fills from same weekday and hour", func(t *testing.T) {
+		week := 7 * 24 * time.Hour
+		profile := MeasurementsWrapper{
+			Resolution: "hour",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: from}, Value: float(3)},
+				{TimeStamp: FlexibleTime{Time: from.Add(week)}, Value: float(5)},
+				{TimeStamp: FlexibleTime{Time: from.Add(2 * week)}, Value: nil},
+			},
+		}
+
+		result, err := FillGaps(profile, FillProfile, nil)
+
+		assert.NoError(t, err)
+		last := result.Measurements[len(result.Measurements)-1]
+		assert.True(t, last.Estimated)
+		assert.Equal(t, 4.0, *last.Value)
+
+		// Hours without any history on the same weekday and hour stay nil
+		assert.Nil(t, result.Measurements[1].Value)
+		assert.False(t, result.Measurements[1].Estimated)
+	})
+
+	t.Run("converts back to measurements wrapper", func(t *testing.T) {
+		result, err := FillGaps(m, FillZero, nil)
+		assert.NoError(t, err)
+
+		wrapper := result.Wrapper()
+		assert.Equal(t, "hour", wrapper.Resolution)
+		assert.Len(t, wrapper.Measurements, 5)
+	})
+
+	t.Run("rejects invalid strategy and resolution", func(t *testing.T) {
+		_, err := FillGaps(m, FillStrategy("spline"), nil)
+		assert.Error(t, err)
+
+		_, err = FillGaps(MeasurementsWrapper{Resolution: "isoweek"}, FillZero, nil)
+		assert.Error(t, err)
+	})
+}
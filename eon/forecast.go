@@ -0,0 +1,147 @@
+package eon
+
+import (
+	"fmt"
+	"time"
+)
+
+// ForecastOptions configures GetForecast
+type ForecastOptions struct {
+	SeriesID      int            // Consumption series, detected with FindConsumptionSeries if zero
+	HistoryMonths int            // Months of costs before the current month used for the unit price, 3 if zero
+	Budget        float64        // Monthly budget, not compared if zero
+	IncludeVAT    bool           // Compare the projected cost including VAT with the budget
+	Location      *time.Location // Time zone of the month, UTC if nil
+}
+
+// MonthForecast projects the consumption and cost of a month from its consumption so far.
+//
+// Consumption is in the unit of the series. Elapsed is the fraction (0-1) of the month covered by
+// measurements; projections extrapolate the consumption so far linearly over the whole month.
+// Costs are estimated with the average unit price of the preceding months, as actual costs are
+// only available after the month has ended.
+type MonthForecast struct {
+	Installation         string    `json:"installation"`
+	SeriesID             int       `json:"seriesId"`
+	Unit                 string    `json:"unit"`
+	Month                time.Time `json:"month"`
+	MeasuredUntil        time.Time `json:"measuredUntil"`
+	Elapsed              float64   `json:"elapsed"`
+	Consumption          float64   `json:"consumption"`
+	ProjectedConsumption float64   `json:"projectedConsumption"`
+	UnitPrice            float64   `json:"unitPrice"`
+	UnitPriceInclVAT     float64   `json:"unitPriceInclVAT"`
+	Cost                 float64   `json:"cost"`
+	CostInclVAT          float64   `json:"costInclVAT"`
+	ProjectedCost        float64   `json:"projectedCost"`
+	ProjectedCostInclVAT float64   `json:"projectedCostInclVAT"`
+	Budget               *float64  `json:"budget"`
+	BudgetUsage          *float64  `json:"budgetUsage"` // Projected cost as a fraction of the budget
+	OverBudget           bool      `json:"overBudget"`
+}
+
+// GetForecast projects the consumption and cost of the month containing now for an installation,
+// combining month-to-date hourly consumption with the unit price of the preceding months
+// (see GetUnitPrices). ErrorNotFound is returned if there is no cost history to price consumption with.
+//
+// Example:
+//
+//	forecast, err := eon.GetForecast(client, "735999163005019944", time.Now(), eon.ForecastOptions{Budget: 20000})
+func GetForecast(c Client, installationID string, now time.Time, opts ForecastOptions) (MonthForecast, error) {
+	if opts.HistoryMonths == 0 {
+		opts.HistoryMonths = 3
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	now = now.In(loc)
+	month := Interval{From: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)}
+	month.To = month.From.AddDate(0, 1, 0)
+
+	series, err := consumptionSeries(c, installationID, opts.SeriesID)
+	if err != nil {
+		return MonthForecast{}, err
+	}
+
+	historyFrom := month.From.AddDate(0, -opts.HistoryMonths, 0)
+	historyTo := month.From.Add(-time.Second)
+	prices, err := getUnitPrices(c, installationID, series, &historyFrom, &historyTo)
+	if err != nil {
+		return MonthForecast{}, err
+	}
+	if len(prices.Months) == 0 {
+		return MonthForecast{}, fmt.Errorf("cost history of installation %s since %s: %w", installationID, historyFrom.Format("2006-01"), ErrorNotFound)
+	}
+
+	measurements, err := c.GetMeasurements(series.ID, Hour, month.From.UTC(), now.UTC(), false)
+	if err != nil {
+		return MonthForecast{}, err
+	}
+
+	// Price consumption in the unit of the unit prices
+	measurements, unit := inKWh(measurements, series.Unit)
+
+	forecast := ForecastMonth(measurements.Between(month.From, now), prices, month)
+	forecast.Installation = installationID
+	forecast.SeriesID = series.ID
+	if forecast.Unit == "" {
+		forecast.Unit = unit
+	}
+	if opts.Budget != 0 {
+		forecast.CompareBudget(opts.Budget, opts.IncludeVAT)
+	}
+	return forecast, nil
+}
+
+// ForecastMonth projects hourly month-to-date measurements over the month and prices them
+// with the total unit prices of a UnitPriceReport
+func ForecastMonth(measurements MeasurementsWrapper, prices UnitPriceReport, month Interval) MonthForecast {
+	forecast := MonthForecast{
+		Installation:     prices.Installation,
+		SeriesID:         measurements.ID,
+		Unit:             prices.Unit,
+		Month:            month.From,
+		UnitPrice:        prices.Total.TotalExclVAT,
+		UnitPriceInclVAT: prices.Total.TotalInclVAT,
+	}
+
+	for _, m := range measurements.Measurements {
+		if m.Value == nil {
+			continue
+		}
+		forecast.Consumption += *m.Value
+		if end := m.TimeStamp.Add(time.Hour); end.After(forecast.MeasuredUntil) {
+			forecast.MeasuredUntil = end
+		}
+	}
+
+	if !forecast.MeasuredUntil.IsZero() {
+		forecast.Elapsed = float64(forecast.MeasuredUntil.Sub(month.From)) / float64(month.To.Sub(month.From))
+		forecast.ProjectedConsumption = forecast.Consumption / forecast.Elapsed
+	}
+
+	forecast.Cost = forecast.Consumption * forecast.UnitPrice
+	forecast.CostInclVAT = forecast.Consumption * forecast.UnitPriceInclVAT
+	forecast.ProjectedCost = forecast.ProjectedConsumption * forecast.UnitPrice
+	forecast.ProjectedCostInclVAT = forecast.ProjectedConsumption * forecast.UnitPriceInclVAT
+
+	return forecast
+}
+
+// CompareBudget sets the budget, the projected share of it and whether it will be exceeded
+func (f *MonthForecast) CompareBudget(budget float64, includeVAT bool) {
+	projected := f.ProjectedCost
+	if includeVAT {
+		projected = f.ProjectedCostInclVAT
+	}
+
+	f.Budget = &budget
+	f.BudgetUsage = nil
+	if budget != 0 {
+		usage := projected / budget
+		f.BudgetUsage = &usage
+	}
+	f.OverBudget = projected > budget
+}
@@ -0,0 +1,131 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastMonth(t *testing.T) {
+	april := Interval{From: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	prices := UnitPriceReport{
+		Installation: "inst-1",
+		Unit:         "KWH",
+		Total:        UnitPricePeriod{TotalExclVAT: 2, TotalInclVAT: 2.5},
+	}
+
+	t.Run("projects linearly over the month", func(t *testing.T) {
+		// Three days of 10 kWh per hour out of 30
+		var values []*float64
+		for i := 0; i < 3*24; i++ {
+			values = append(values, float(10))
+		}
+		values = append(values, nil)
+		m := hourlySeries(april.From, values...)
+
+		forecast := ForecastMonth(m, prices, april)
+
+		assert.Equal(t, "inst-1", forecast.Installation)
+		assert.Equal(t, april.From.AddDate(0, 0, 3), forecast.MeasuredUntil)
+		assert.InDelta(t, 0.1, forecast.Elapsed, 1e-9)
+		assert.Equal(t, 720.0, forecast.Consumption)
+		assert.InDelta(t, 7200, forecast.ProjectedConsumption, 1e-6)
+		assert.Equal(t, 1440.0, forecast.Cost)
+		assert.Equal(t, 1800.0, forecast.CostInclVAT)
+		assert.InDelta(t, 14400, forecast.ProjectedCost, 1e-6)
+		assert.InDelta(t, 18000, forecast.ProjectedCostInclVAT, 1e-6)
+		assert.Nil(t, forecast.Budget)
+	})
+
+	t.Run("handles months without measurements", func(t *testing.T) {
+		forecast := ForecastMonth(MeasurementsWrapper{}, prices, april)
+
+		assert.Equal(t, 0.0, forecast.Elapsed)
+		assert.Equal(t, 0.0, forecast.ProjectedCost)
+	})
+}
+
+func TestCompareBudget(t *testing.T) {
+	forecast := MonthForecast{ProjectedCost: 800, ProjectedCostInclVAT: 1000}
+
+	forecast.CompareBudget(900, false)
+	assert.Equal(t, 900.0, *forecast.Budget)
+	assert.InDelta(t, 0.889, *forecast.BudgetUsage, 0.001)
+	assert.False(t, forecast.OverBudget)
+
+	forecast.CompareBudget(900, true)
+	assert.True(t, forecast.OverBudget)
+}
+
+func TestGetForecast(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	now := time.Date(2024, 4, 16, 0, 30, 0, 0, time.UTC)
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 2, SeriesType: "ElectricActive", Unit: "KWH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"energyClass":  "El",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{"month": "2024-03-01T00:00:00", "retailCost": 300.0, "retailCostVAT": 375.0},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/2/resolution/month",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         2,
+			Resolution: "month",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, Value: float(100)},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/2/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         2,
+			Resolution: "hour",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)}, Value: float(1000)},
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 4, 15, 23, 0, 0, 0, time.UTC)}, Value: float(50)},
+			},
+		}))
+
+	t.Run("projects cost against budget", func(t *testing.T) {
+		forecast, err := GetForecast(c, "inst-1", now, ForecastOptions{Budget: 250})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, forecast.SeriesID)
+		assert.Equal(t, "kWh", forecast.Unit)
+		assert.Equal(t, 3.0, forecast.UnitPrice)
+		assert.Equal(t, 50.0, forecast.Consumption)
+		assert.InDelta(t, 0.5, forecast.Elapsed, 1e-9)
+		assert.InDelta(t, 300, forecast.ProjectedCost, 1e-6)
+		assert.True(t, forecast.OverBudget)
+	})
+
+	t.Run("requires cost history", func(t *testing.T) {
+		httpmock.RegisterResponder("GET", "/costs/inst-1",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{"energyClass": "El", "installation": "inst-1", "costs": []interface{}{}}))
+
+		_, err := GetForecast(c, "inst-1", now.AddDate(1, 0, 0), ForecastOptions{})
+
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+}
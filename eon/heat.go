@@ -0,0 +1,282 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// waterHeatCapacity is the volumetric heat capacity of water in kWh per m³ and kelvin
+const waterHeatCapacity = 1.163
+
+// HeatOptions configures GetHeatAnalysis
+type HeatOptions struct {
+	EnergySeriesID int              // Detected with IsConsumptionSeries if zero
+	FlowSeriesID   int              // Detected with IsFlowSeries if zero; no delta-T without a flow series
+	Temperatures   []Temperature    // Daily outdoor temperatures for degree-day normalisation, optional
+	DegreeDays     DegreeDayOptions // Base temperatures of the degree-days
+	Location       *time.Location   // Time zone of months and days, UTC if nil
+}
+
+// HeatMonth relates the costs of a month of district heating or cooling to its measured energy and flow.
+//
+// DeltaT is the average temperature difference between supply and return water derived from energy
+// and flow; a higher delta-T means the water is used more efficiently. DegreeDays are heating
+// degree-days for heat and cooling degree-days for cold, and EnergyPerDegreeDay the weather-normalised
+// consumption. Components hold the effect, energy, flow and retail costs of the month.
+type HeatMonth struct {
+	Period             string               `json:"period"`
+	Start              time.Time            `json:"start"`
+	Energy             float64              `json:"energy"` // kWh
+	Flow               *float64             `json:"flow"`   // m³
+	DeltaT             *float64             `json:"deltaT"` // K
+	DegreeDays         *float64             `json:"degreeDays"`
+	EnergyPerDegreeDay *float64             `json:"energyPerDegreeDay"`
+	Components         []CostComponentTotal `json:"components"`
+	TotalExclVAT       float64              `json:"totalExclVAT"`
+	TotalInclVAT       float64              `json:"totalInclVAT"`
+	CostPerKWh         *float64             `json:"costPerKWh"`
+	EnergyCostPerKWh   *float64             `json:"energyCostPerKWh"`
+	FlowCostPerM3      *float64             `json:"flowCostPerM3"`
+}
+
+// HeatReport holds monthly heat or cold analytics of an installation
+type HeatReport struct {
+	Installation string        `json:"installation"`
+	EnergyClass  string        `json:"energyClass"`
+	Carrier      EnergyCarrier `json:"carrier"`
+	EnergySeries int           `json:"energySeries"`
+	FlowSeries   int           `json:"flowSeries"`
+	Months       []HeatMonth   `json:"months"`
+	Total        HeatMonth     `json:"total"`
+}
+
+// IsFlowSeries reports whether a measurement series looks like a water flow series,
+// i.e. it is measured in m³ or its type mentions flow or volume.
+func IsFlowSeries(series MeasurementSeriesDto) bool {
+	switch strings.ToUpper(series.Unit) {
+	case "M3", "M³":
+		return true
+	}
+	seriesType := strings.ToLower(series.SeriesType)
+	return strings.Contains(seriesType, "flow") || strings.Contains(seriesType, "volume")
+}
+
+// GetHeatAnalysis fetches the costs, daily energy and flow of a district heating or cooling
+// installation and relates them per month (see AnalyzeHeat).
+// ErrorNotFound is returned if no energy series can be found.
+//
+// Example:
+//
+//	temperatures, _ := eon.LoadTemperatureFile("smhi-stockholm.csv", stockholm)
+//	report, err := eon.GetHeatAnalysis(client, "735999163005019944", from, to, eon.HeatOptions{Temperatures: temperatures})
+func GetHeatAnalysis(c Client, installationID string, from, to time.Time, opts HeatOptions) (HeatReport, error) {
+	series, err := c.GetMeasurementSeries()
+	if err != nil {
+		return HeatReport{}, err
+	}
+
+	energy := MeasurementSeriesDto{ID: opts.EnergySeriesID}
+	flow := MeasurementSeriesDto{ID: opts.FlowSeriesID}
+	for _, inst := range series.Installations {
+		if inst.ID != installationID {
+			continue
+		}
+		for _, ms := range inst.MeasurementSeries {
+			switch {
+			case ms.ID == opts.EnergySeriesID:
+				energy = ms
+			case ms.ID == opts.FlowSeriesID:
+				flow = ms
+			case opts.EnergySeriesID == 0 && energy.ID == 0 && IsConsumptionSeries(ms):
+				energy = ms
+			case opts.FlowSeriesID == 0 && flow.ID == 0 && IsFlowSeries(ms):
+				flow = ms
+			}
+		}
+	}
+	if energy.ID == 0 {
+		return HeatReport{}, fmt.Errorf("energy series for installation %s: %w", installationID, ErrorNotFound)
+	}
+
+	costs, err := c.GetCosts(installationID, &from, &to)
+	if err != nil {
+		return HeatReport{}, err
+	}
+
+	energyMeasurements, err := c.GetMeasurements(energy.ID, Day, from.UTC(), to.UTC(), false)
+	if err != nil {
+		return HeatReport{}, err
+	}
+
+	var flowMeasurements MeasurementsWrapper
+	if flow.ID != 0 {
+		if flowMeasurements, err = c.GetMeasurements(flow.ID, Day, from.UTC(), to.UTC(), false); err != nil {
+			return HeatReport{}, err
+		}
+	}
+
+	var degreeDays []DegreeDay
+	if len(opts.Temperatures) > 0 {
+		degreeDays = DegreeDays(opts.Temperatures, opts.DegreeDays)
+	}
+
+	report, err := AnalyzeHeat(costs, energyMeasurements, flowMeasurements, energy.Unit, degreeDays, opts.Location)
+	if err != nil {
+		return HeatReport{}, err
+	}
+	if report.Installation == "" {
+		report.Installation = installationID
+	}
+	report.EnergySeries = energy.ID
+	report.FlowSeries = flow.ID
+	return report, nil
+}
+
+// AnalyzeHeat relates the result of GetCosts for a heat or cold installation to daily energy and
+// flow (in m³) measurements and degree-days per month. The flow and degree-days are optional.
+// Degree-days should cover whole months for EnergyPerDegreeDay to be meaningful.
+func AnalyzeHeat(costs interface{}, energy, flow MeasurementsWrapper, energyUnit string, degreeDays []DegreeDay, loc *time.Location) (HeatReport, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	summary, err := SummarizeCosts(costs, PeriodMonth)
+	if err != nil {
+		return HeatReport{}, err
+	}
+	carrier, err := CarrierOf(summary.EnergyClass)
+	if err != nil {
+		return HeatReport{}, err
+	}
+	if carrier != CarrierHeat && carrier != CarrierCold {
+		return HeatReport{}, fmt.Errorf("installation %s is not a heat or cold installation: %s", summary.Installation, summary.EnergyClass)
+	}
+
+	report := HeatReport{
+		Installation: summary.Installation,
+		EnergyClass:  summary.EnergyClass,
+		Carrier:      carrier,
+		EnergySeries: energy.ID,
+		FlowSeries:   flow.ID,
+		Months:       []HeatMonth{},
+	}
+
+	// Daily values are dated by the calendar date in their own time zone: moving the UTC midnight
+	// timestamps of the API into loc would put the first day of a month in the previous month west of UTC
+	months := map[string]*HeatMonth{}
+	monthOf := func(t time.Time) *HeatMonth {
+		name := t.Format("2006-01")
+		m, ok := months[name]
+		if !ok {
+			m = &HeatMonth{Period: name, Start: time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc), Components: []CostComponentTotal{}}
+			months[name] = m
+		}
+		return m
+	}
+
+	for _, measurement := range energy.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		kWh, err := ToKWh(*measurement.Value, energyUnit)
+		if err != nil {
+			return HeatReport{}, err
+		}
+		monthOf(measurement.TimeStamp.Time).Energy += kWh
+	}
+	for _, measurement := range flow.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		m := monthOf(measurement.TimeStamp.Time)
+		m.Flow = addTo(m.Flow, *measurement.Value)
+	}
+	for _, d := range degreeDays {
+		value := d.Heating
+		if carrier == CarrierCold {
+			value = d.Cooling
+		}
+		m := monthOf(d.Date)
+		m.DegreeDays = addTo(m.DegreeDays, value)
+	}
+
+	// Costs are reported per calendar month and matched by name
+	for _, p := range summary.Periods {
+		m, ok := months[p.Period]
+		if !ok {
+			m = &HeatMonth{Period: p.Period, Start: time.Date(p.Start.Year(), p.Start.Month(), 1, 0, 0, 0, 0, loc)}
+			months[p.Period] = m
+		}
+		m.Components = p.Components
+		m.TotalExclVAT = p.TotalExclVAT
+		m.TotalInclVAT = p.TotalInclVAT
+	}
+
+	report.Total = HeatMonth{Period: "total", Components: make([]CostComponentTotal, len(summary.Total.Components))}
+	copy(report.Total.Components, summary.Total.Components)
+	for _, m := range months {
+		// Degree-days of months without consumption, e.g. beyond the range, aren't part of the total
+		if m.Energy == 0 && m.Flow == nil && len(m.Components) == 0 {
+			continue
+		}
+		m.ratios()
+		report.Months = append(report.Months, *m)
+
+		if report.Total.Start.IsZero() || m.Start.Before(report.Total.Start) {
+			report.Total.Start = m.Start
+		}
+		report.Total.Energy += m.Energy
+		report.Total.TotalExclVAT += m.TotalExclVAT
+		report.Total.TotalInclVAT += m.TotalInclVAT
+		if m.Flow != nil {
+			report.Total.Flow = addTo(report.Total.Flow, *m.Flow)
+		}
+		if m.DegreeDays != nil {
+			report.Total.DegreeDays = addTo(report.Total.DegreeDays, *m.DegreeDays)
+		}
+	}
+	sort.Slice(report.Months, func(i, j int) bool { return report.Months[i].Start.Before(report.Months[j].Start) })
+	report.Total.ratios()
+
+	return report, nil
+}
+
+// Component returns the cost of a component (e.g. "effectCost") excluding VAT, or false if it has none
+func (m HeatMonth) Component(name string) (float64, bool) {
+	for _, c := range m.Components {
+		if c.Component == name {
+			return c.ExclVAT, true
+		}
+	}
+	return 0, false
+}
+
+// ratios computes the delta-T, normalised energy and unit costs of a month
+func (m *HeatMonth) ratios() {
+	m.DeltaT, m.EnergyPerDegreeDay, m.CostPerKWh, m.EnergyCostPerKWh, m.FlowCostPerM3 = nil, nil, nil, nil, nil
+
+	if m.Flow != nil && *m.Flow != 0 {
+		deltaT := m.Energy / (*m.Flow * waterHeatCapacity)
+		m.DeltaT = &deltaT
+
+		if cost, ok := m.Component("flowCost"); ok {
+			perM3 := cost / *m.Flow
+			m.FlowCostPerM3 = &perM3
+		}
+	}
+	if m.DegreeDays != nil && *m.DegreeDays != 0 {
+		perDegreeDay := m.Energy / *m.DegreeDays
+		m.EnergyPerDegreeDay = &perDegreeDay
+	}
+	if m.Energy != 0 {
+		perKWh := m.TotalExclVAT / m.Energy
+		m.CostPerKWh = &perKWh
+
+		if cost, ok := m.Component("energyCost"); ok {
+			energyPerKWh := cost / m.Energy
+			m.EnergyCostPerKWh = &energyPerKWh
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// heatCosts returns district heating costs of a month
+func heatCosts(energyClass string, month time.Time) CostsHeatWrapper {
+	return CostsHeatWrapper{
+		CostsWrapper: CostsWrapper{EnergyClass: energyClass, Installation: "inst-1"},
+		Costs: []CostHeatColdDto{{
+			CostsBaseDto: CostsBaseDto{Month: FlexibleTime{month}},
+			EffectCost:   float(300),
+			EnergyCost:   float(500),
+			FlowCost:     float(200),
+		}},
+	}
+}
+
+func TestIsFlowSeries(t *testing.T) {
+	assert.True(t, IsFlowSeries(MeasurementSeriesDto{SeriesType: "HeatVolume", Unit: "M3"}))
+	assert.True(t, IsFlowSeries(MeasurementSeriesDto{SeriesType: "Flow"}))
+	assert.False(t, IsFlowSeries(MeasurementSeriesDto{SeriesType: "HeatEnergy", Unit: "MWH"}))
+}
+
+func TestAnalyzeHeat(t *testing.T) {
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	energy := dailySeries(january, 2, 3) // MWh
+	flow := dailySeries(january, 40, 46)
+	degreeDays := []DegreeDay{
+		{Date: january, Heating: 20, Cooling: 0},
+		{Date: january.AddDate(0, 0, 1), Heating: 30},
+		{Date: january.AddDate(0, 1, 0), Heating: 25}, // Outside the range
+	}
+
+	report, err := AnalyzeHeat(heatCosts("Fjärrvärme", january), energy, flow, "MWH", degreeDays, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, CarrierHeat, report.Carrier)
+	assert.Len(t, report.Months, 1)
+
+	month := report.Months[0]
+	assert.Equal(t, "2024-01", month.Period)
+	assert.Equal(t, 5000.0, month.Energy)
+	assert.Equal(t, 86.0, *month.Flow)
+	assert.InDelta(t, 49.99, *month.DeltaT, 0.01)
+	assert.Equal(t, 50.0, *month.DegreeDays)
+	assert.Equal(t, 100.0, *month.EnergyPerDegreeDay)
+	assert.Equal(t, 1000.0, month.TotalExclVAT)
+	assert.Equal(t, 0.2, *month.CostPerKWh)
+	assert.Equal(t, 0.1, *month.EnergyCostPerKWh)
+	assert.InDelta(t, 2.33, *month.FlowCostPerM3, 0.01)
+
+	effect, ok := month.Component("effectCost")
+	assert.True(t, ok)
+	assert.Equal(t, 300.0, effect)
+
+	assert.Equal(t, 50.0, *report.Total.DegreeDays)
+	assert.Equal(t, 5000.0, report.Total.Energy)
+
+	t.Run("works without flow and temperatures", func(t *testing.T) {
+		report, err := AnalyzeHeat(heatCosts("Fjärrkyla", january), energy, MeasurementsWrapper{}, "MWH", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, CarrierCold, report.Carrier)
+		assert.Nil(t, report.Months[0].DeltaT)
+		assert.Nil(t, report.Months[0].EnergyPerDegreeDay)
+	})
+
+	t.Run("uses cooling degree-days for cold", func(t *testing.T) {
+		report, err := AnalyzeHeat(heatCosts("Cold", january), energy, MeasurementsWrapper{}, "MWH", degreeDays, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, *report.Months[0].DegreeDays)
+		assert.Nil(t, report.Months[0].EnergyPerDegreeDay)
+	})
+
+	t.Run("keeps UTC days in their month west of UTC", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		assert.NoError(t, err)
+		february := january.AddDate(0, 1, 0)
+
+		report, err := AnalyzeHeat(heatCosts("Fjärrvärme", january), dailySeries(february, 1), MeasurementsWrapper{}, "MWH", nil, newYork)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Months, 2)
+		assert.Equal(t, "2024-02", report.Months[1].Period)
+		assert.Equal(t, 1000.0, report.Months[1].Energy)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, newYork), report.Months[1].Start)
+	})
+
+	t.Run("rejects electricity installations", func(t *testing.T) {
+		_, err := AnalyzeHeat(heatCosts("El", january), energy, flow, "MWH", nil, nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetHeatAnalysis(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{
+					{ID: 1, SeriesType: "HeatVolume", Unit: "M3"},
+					{ID: 2, SeriesType: "HeatEnergy", Unit: "KWH"},
+				}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, heatCosts("Värme", january)))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/day",
+		httpmock.NewJsonResponderOrPanic(200, dailySeries(january, 10)))
+	httpmock.RegisterResponder("GET", "/measurements/2/resolution/day",
+		httpmock.NewJsonResponderOrPanic(200, dailySeries(january, 581.5)))
+
+	report, err := GetHeatAnalysis(c, "inst-1", january, january.AddDate(0, 1, -1), HeatOptions{
+		Temperatures: []Temperature{{Date: january, Mean: 7}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.EnergySeries)
+	assert.Equal(t, 1, report.FlowSeries)
+	assert.InDelta(t, 50, *report.Total.DeltaT, 1e-9)
+	assert.Equal(t, 10.0, *report.Total.DegreeDays)
+}
@@ -0,0 +1,90 @@
+package eon
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// Hooks observe or modify the HTTP requests of the client, including token requests, e.g. to add
+// headers, audit or collect metrics. Hooks run for every attempt of a request. Hooks added later
+// wrap earlier ones: their BeforeRequest runs first and their AfterResponse last.
+type Hooks struct {
+	// BeforeRequest is called before a request is sent and may modify it, e.g. to add headers.
+	// Returning an error aborts the request.
+	BeforeRequest func(req *http.Request) error
+	// AfterResponse is called with the response of a request, whose body can be read.
+	// Returning an error fails the request.
+	AfterResponse func(req *http.Request, res *http.Response) error
+	// OnError is called when a request fails without response, or a hook fails it
+	OnError func(req *http.Request, err error)
+}
+
+// WithHooks adds hooks to the requests of the client. When added after WithCache,
+// hooks also see responses served from the cache.
+//
+// Example:
+//
+//	client := eon.New(eon.WithHooks(eon.Hooks{
+//		BeforeRequest: func(req *http.Request) error {
+//			req.Header.Set("X-Request-ID", uuid.NewString())
+//			return nil
+//		},
+//	}))
+func WithHooks(hooks Hooks) Option {
+	return func(c *client) {
+		for _, httpClient := range []*http.Client{c.resty.GetClient(), c.tokenClient().GetClient()} {
+			next := httpClient.Transport
+			if next == nil {
+				next = http.DefaultTransport
+			}
+			httpClient.Transport = &hookTransport{next: next, hooks: hooks}
+		}
+	}
+}
+
+// hookTransport runs Hooks around requests
+type hookTransport struct {
+	next  http.RoundTripper
+	hooks Hooks
+}
+
+// RoundTrip runs the hooks around the request
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request
+	req = req.Clone(req.Context())
+
+	if t.hooks.BeforeRequest != nil {
+		if err := t.hooks.BeforeRequest(req); err != nil {
+			return nil, t.fail(req, err)
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, t.fail(req, err)
+	}
+
+	if t.hooks.AfterResponse != nil {
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return nil, t.fail(req, err)
+		}
+
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		if err := t.hooks.AfterResponse(req, res); err != nil {
+			return nil, t.fail(req, err)
+		}
+		res.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return res, nil
+}
+
+// fail calls the OnError hook and returns err
+func (t *hookTransport) fail(req *http.Request, err error) error {
+	if t.hooks.OnError != nil {
+		t.hooks.OnError(req, err)
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+package eon
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHooks(t *testing.T) {
+	mockResty := resty.New()
+	mockAuth := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	httpmock.ActivateNonDefault(mockAuth.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		resty:        mockResty,
+		auth:         mockAuth,
+	}
+
+	var requests, bodies []string
+	var failures []error
+	WithHooks(Hooks{
+		BeforeRequest: func(req *http.Request) error {
+			req.Header.Set("X-Request-ID", "req-1")
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			return nil
+		},
+		AfterResponse: func(req *http.Request, res *http.Response) error {
+			body, err := io.ReadAll(res.Body)
+			bodies = append(bodies, string(body))
+			return err
+		},
+		OnError: func(req *http.Request, err error) {
+			failures = append(failures, err)
+		},
+	})(c)
+
+	httpmock.RegisterResponder("POST", tokenEndpoint,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "req-1", req.Header.Get("X-Request-ID"))
+			return httpmock.NewJsonResponse(200, OAuth2TokenResponse{AccessToken: "fake-token", ExpiresIn: 3600})
+		})
+	httpmock.RegisterResponder("GET", "/installations",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "req-1", req.Header.Get("X-Request-ID"))
+			return httpmock.NewJsonResponse(200, InstallationsWrapper{Installations: []InstallationDto{{ID: "inst-1"}}})
+		})
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewErrorResponder(errors.New("connection reset")))
+
+	t.Run("wraps token and API requests", func(t *testing.T) {
+		result, err := c.GetInstallations(nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "inst-1", result.Installations[0].ID)
+		assert.Len(t, requests, 2)
+		assert.Contains(t, requests[0], "POST")
+		assert.Equal(t, "GET /installations", requests[1])
+		assert.Contains(t, bodies[1], "inst-1")
+	})
+
+	t.Run("reports errors", func(t *testing.T) {
+		_, err := c.GetMeasurementSeries()
+		assert.Error(t, err)
+
+		assert.Len(t, failures, 1)
+		assert.ErrorContains(t, failures[0], "connection reset")
+	})
+}
+
+func TestHooksAbortRequests(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	var failed error
+	WithHooks(Hooks{
+		BeforeRequest: func(req *http.Request) error { return errors.New("blocked") },
+		OnError:       func(req *http.Request, err error) { failed = err },
+	})(c)
+
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{}))
+
+	_, err := c.GetInstallations(nil)
+
+	assert.ErrorContains(t, err, "blocked")
+	assert.EqualError(t, failed, "blocked")
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
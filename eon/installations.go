@@ -12,26 +12,12 @@ import (
 //
 //	installations, err := client.GetInstallations([]string{"installation-id-1", "installation-id-2"})
 func (c *client) GetInstallations(filter []string) (InstallationsWrapper, error) {
-	accessToken, err := c.GetAccessToken()
+	req, err := c.request("GetInstallations", nil)
 	if err != nil {
 		return InstallationsWrapper{}, err
 	}
 
-	var result InstallationsWrapper
-
-	req := c.resty.R().
-		SetAuthToken(accessToken).
-		SetResult(&result)
-
-	// Add filter query parameters if provided
-	if len(filter) > 0 {
-		// Use SetQueryParamsFromValues for array parameters
-		params := make(map[string][]string)
-		params["installationFilter"] = filter
-		req = req.SetQueryParamsFromValues(params)
-	}
-
-	res, err := req.Get("/installations")
+	result, res, err := getInstallations(req, getInstallationsParams{InstallationFilter: filter})
 	if err != nil {
 		return InstallationsWrapper{}, err
 	}
@@ -53,18 +39,12 @@ func (c *client) GetInstallations(filter []string) (InstallationsWrapper, error)
 //
 //	series, err := client.GetMeasurementSeries()
 func (c *client) GetMeasurementSeries() (InstallationsMeasurementsWrapper, error) {
-	accessToken, err := c.GetAccessToken()
+	req, err := c.request("GetMeasurementSeries", nil)
 	if err != nil {
 		return InstallationsMeasurementsWrapper{}, err
 	}
 
-	var result InstallationsMeasurementsWrapper
-
-	res, err := c.resty.R().
-		SetAuthToken(accessToken).
-		SetResult(&result).
-		Get("/installations/measurement-series")
-
+	result, res, err := getInstallationsMeasurementSeries(req)
 	if err != nil {
 		return InstallationsMeasurementsWrapper{}, err
 	}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// header marks generated files, see https://go.dev/s/generatedcode
+const header = "// Code generated by swaggergen from docs/swagger.json. DO NOT EDIT.\n\n"
+
+// Generated file names
+const (
+	modelsFile    = "models_gen.go"
+	endpointsFile = "endpoints_gen.go"
+)
+
+// options configures generate
+type options struct {
+	Package    string // Package of the generated code
+	PathPrefix string // Prefix of spec paths already in the client's base URL, e.g. /api
+}
+
+// generate returns the generated models and endpoint functions of a spec by file name
+func generate(data []byte, opts options) (map[string][]byte, error) {
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	models, err := generateModels(&s, opts)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := generateEndpoints(&s, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for name, src := range map[string]string{modelsFile: models, endpointsFile: endpoints} {
+		formatted, err := format.Source([]byte(src))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format %s: %w\n%s", name, err, src)
+		}
+		files[name] = formatted
+	}
+	return files, nil
+}
+
+// generateModels returns the source of a struct per schema
+func generateModels(s *spec, opts options) (string, error) {
+	var b strings.Builder
+	b.WriteString(header)
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+
+	for _, name := range s.Components.Schemas.Keys {
+		sch := s.Components.Schemas.Values[name]
+		if sch.Type != "object" {
+			return "", fmt.Errorf("schema %s: unsupported type %q", name, sch.Type)
+		}
+
+		comment := fmt.Sprintf("%s is the %s schema of the API.", name, name)
+		if sch.Description != "" {
+			comment = name + " " + clean(sch.Description)
+		}
+		b.WriteString(wrap(comment, "// "))
+		fmt.Fprintf(&b, "type %s struct {\n", name)
+
+		for _, embedded := range sch.AllOf {
+			if embedded.Ref == "" {
+				return "", fmt.Errorf("schema %s: only references are supported in allOf", name)
+			}
+			fmt.Fprintf(&b, "\t%s\n", refName(embedded.Ref))
+		}
+		for _, prop := range sch.Properties.Keys {
+			propSchema := sch.Properties.Values[prop]
+			typ, err := goType(propSchema, true)
+			if err != nil {
+				return "", fmt.Errorf("schema %s: property %s: %w", name, prop, err)
+			}
+			if propSchema.Description != "" {
+				b.WriteString(wrap(clean(propSchema.Description), "\t// "))
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", exportName(prop), typ, prop)
+		}
+		b.WriteString("}\n\n")
+	}
+	return b.String(), nil
+}
+
+// generateEndpoints returns the source of a function per operation, sending the request and
+// decoding a successful response. Status handling is left to the client.
+func generateEndpoints(s *spec, opts options) (string, error) {
+	var body strings.Builder
+	imports := map[string]bool{}
+
+	for _, path := range s.Paths.Keys {
+		methods := s.Paths.Values[path]
+		names := make([]string, 0, len(methods))
+		for method := range methods {
+			names = append(names, method)
+		}
+		sort.Strings(names)
+
+		for _, method := range names {
+			if err := generateEndpoint(&body, imports, strings.TrimPrefix(path, opts.PathPrefix), method, methods[method]); err != nil {
+				return "", fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", opts.Package)
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	b.WriteString("\n\t\"github.com/go-resty/resty/v2\"\n)\n\n")
+	b.WriteString(body.String())
+	return b.String(), nil
+}
+
+// generateEndpoint writes the function of an operation and the struct of its query parameters
+func generateEndpoint(b *strings.Builder, imports map[string]bool, path, method string, op *operation) error {
+	name := operationName(method, path)
+
+	result := "interface{}"
+	var oneOf []string
+	if success := op.Responses["200"]; success != nil {
+		if content, ok := success.Content["application/json"]; ok && content.Schema != nil {
+			switch {
+			case content.Schema.Ref != "":
+				result = refName(content.Schema.Ref)
+			case len(content.Schema.OneOf) > 0:
+				for _, option := range content.Schema.OneOf {
+					oneOf = append(oneOf, refName(option.Ref))
+				}
+			}
+		}
+	}
+
+	var pathParams, queryParams []*parameter
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			pathParams = append(pathParams, p)
+		case "query":
+			queryParams = append(queryParams, p)
+		default:
+			return fmt.Errorf("parameter %s: unsupported location %q", p.Name, p.In)
+		}
+	}
+
+	// Query parameters
+	args := []string{"req *resty.Request"}
+	if len(queryParams) > 0 {
+		fmt.Fprintf(b, "// %sParams are the query parameters of %s\n", name, name)
+		fmt.Fprintf(b, "type %sParams struct {\n", name)
+		for _, p := range queryParams {
+			typ, err := paramType(p)
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+			if p.Description != "" {
+				b.WriteString(wrap(clean(p.Description), "\t// "))
+			}
+			fmt.Fprintf(b, "\t%s %s\n", exportName(p.Name), typ)
+		}
+		b.WriteString("}\n\n")
+	}
+	for _, p := range pathParams {
+		typ, err := paramType(p)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		args = append(args, fmt.Sprintf("%s %s", unexportName(p.Name), typ))
+	}
+	if len(queryParams) > 0 {
+		args = append(args, fmt.Sprintf("params %sParams", name))
+	}
+
+	// Function
+	comment := fmt.Sprintf("%s calls %s %s", name, strings.ToUpper(method), path)
+	if op.Summary != "" {
+		comment += ": " + clean(op.Summary)
+	}
+	b.WriteString(wrap(comment, "// "))
+	if len(oneOf) > 0 {
+		b.WriteString("//\n")
+		b.WriteString(wrap("The result is one of "+strings.Join(oneOf, ", ")+", depending on the response.", "// "))
+	}
+	fmt.Fprintf(b, "func %s(%s) (%s, *resty.Response, error) {\n", name, strings.Join(args, ", "), result)
+	fmt.Fprintf(b, "\tvar result %s\n", result)
+	b.WriteString("\treq.SetResult(&result)\n")
+
+	for _, p := range pathParams {
+		if p.Schema.Type == "string" {
+			fmt.Fprintf(b, "\treq.SetPathParam(%q, %s)\n", p.Name, unexportName(p.Name))
+			continue
+		}
+		imports["fmt"] = true
+		fmt.Fprintf(b, "\treq.SetPathParam(%q, fmt.Sprint(%s))\n", p.Name, unexportName(p.Name))
+	}
+	for _, p := range queryParams {
+		field := "params." + exportName(p.Name)
+		typ, _ := paramType(p)
+		switch {
+		case strings.HasPrefix(typ, "[]"):
+			imports["net/url"] = true
+			fmt.Fprintf(b, "\tif len(%s) > 0 {\n\t\treq.SetQueryParamsFromValues(url.Values{%q: %s})\n\t}\n", field, p.Name, field)
+		case strings.HasPrefix(typ, "*"):
+			imports["fmt"] = true
+			fmt.Fprintf(b, "\tif %s != nil {\n\t\treq.SetQueryParam(%q, fmt.Sprint(*%s))\n\t}\n", field, p.Name, field)
+		case typ == "string" && !p.Required:
+			fmt.Fprintf(b, "\tif %s != \"\" {\n\t\treq.SetQueryParam(%q, %s)\n\t}\n", field, p.Name, field)
+		default:
+			imports["fmt"] = true
+			fmt.Fprintf(b, "\treq.SetQueryParam(%q, fmt.Sprint(%s))\n", p.Name, field)
+		}
+	}
+
+	fmt.Fprintf(b, "\n\tres, err := req.%s(%q)\n", exportName(strings.ToLower(method)), path)
+	b.WriteString("\treturn result, res, err\n}\n\n")
+	return nil
+}
+
+// goType returns the Go type of a schema. Properties referencing objects are pointers, so they can
+// be absent.
+func goType(s *schema, property bool) (string, error) {
+	if s.Ref != "" {
+		if property {
+			return "*" + refName(s.Ref), nil
+		}
+		return refName(s.Ref), nil
+	}
+
+	var typ string
+	switch s.Type {
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		items, err := goType(s.Items, false)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + items, nil
+	case "string":
+		if s.Format == "date-time" {
+			return "FlexibleTime", nil // Handles null and timestamps without zone
+		}
+		return "string", nil // Null decodes as empty
+	case "integer":
+		typ = "int"
+		if s.Format == "int64" {
+			typ = "int64"
+		}
+	case "number":
+		typ = "float64"
+		if s.Format == "float" {
+			typ = "float32"
+		}
+	case "boolean":
+		typ = "bool"
+	case "":
+		return "interface{}", nil
+	default:
+		return "", fmt.Errorf("unsupported type %q", s.Type)
+	}
+
+	if s.Nullable {
+		return "*" + typ, nil
+	}
+	return typ, nil
+}
+
+// paramType returns the Go type of a parameter. Optional query parameters are pointers or empty
+// strings and slices, so they can be omitted; date-times are formatted by the caller.
+func paramType(p *parameter) (string, error) {
+	if p.Schema == nil {
+		return "", fmt.Errorf("parameter without schema")
+	}
+	switch p.Schema.Type {
+	case "string":
+		return "string", nil
+	case "array":
+		if p.Schema.Items == nil || p.Schema.Items.Type != "string" {
+			return "", fmt.Errorf("only arrays of strings are supported")
+		}
+		return "[]string", nil
+	}
+
+	typ, err := goType(&schema{Type: p.Schema.Type, Format: p.Schema.Format}, false)
+	if err != nil {
+		return "", err
+	}
+	if !p.Required {
+		return "*" + typ, nil
+	}
+	return typ, nil
+}
+
+// operationName returns the function name of an operation from its method and the static segments
+// of its path, e.g. getMeasurementsResolution for GET /measurements/{id}/resolution/{resolution}
+func operationName(method, path string) string {
+	name := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || strings.HasPrefix(segment, "{") {
+			continue
+		}
+		name += exportName(segment)
+	}
+	return name
+}
+
+// exportName returns the exported Go name of a JSON name, e.g. ID for id and
+// MeasurementSeries for measurement-series
+func exportName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if strings.EqualFold(part, "id") {
+			b.WriteString("ID")
+			continue
+		}
+		runes := []rune(part)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	return b.String()
+}
+
+// unexportName returns the unexported Go name of a JSON name
+func unexportName(name string) string {
+	exported := exportName(name)
+	if exported == "ID" {
+		return "id"
+	}
+	runes := []rune(exported)
+	return string(unicode.ToLower(runes[0])) + string(runes[1:])
+}
+
+// refName returns the schema name of a reference
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// clean collapses the whitespace of a description
+func clean(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// wrap returns text as comment lines of at most 100 characters
+func wrap(text, prefix string) string {
+	var b strings.Builder
+	line := prefix
+	for _, word := range strings.Fields(text) {
+		if line != prefix && len(line)+1+len(word) > 100 {
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+			line = prefix
+		}
+		if line != prefix {
+			line += " "
+		}
+		line += word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "docs", "swagger.json"))
+	assert.NoError(t, err)
+
+	files, err := generate(data, options{Package: "eon", PathPrefix: "/api"})
+	assert.NoError(t, err)
+
+	for _, name := range []string{modelsFile, endpointsFile} {
+		checkedIn, err := os.ReadFile(filepath.Join("..", "..", name))
+		assert.NoError(t, err)
+
+		if string(files[name]) != string(checkedIn) {
+			t.Errorf("eon/%s is out of date with docs/swagger.json, run go generate ./eon", name)
+		}
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   schema
+		property bool
+		want     string
+	}{
+		{"string", schema{Type: "string"}, true, "string"},
+		{"nullable string", schema{Type: "string", Nullable: true}, true, "string"},
+		{"date-time", schema{Type: "string", Format: "date-time"}, true, "FlexibleTime"},
+		{"integer", schema{Type: "integer", Format: "int32"}, true, "int"},
+		{"nullable double", schema{Type: "number", Format: "double", Nullable: true}, true, "*float64"},
+		{"nullable float", schema{Type: "number", Format: "float", Nullable: true}, true, "*float32"},
+		{"boolean", schema{Type: "boolean"}, true, "bool"},
+		{"reference property", schema{Ref: "#/components/schemas/CostGridDetailsDto"}, true, "*CostGridDetailsDto"},
+		{"array of references", schema{Type: "array", Items: &schema{Ref: "#/components/schemas/MeasurementDto"}}, true, "[]MeasurementDto"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goType(&tt.schema, tt.property)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		_, err := goType(&schema{Type: "file"}, true)
+		assert.Error(t, err)
+	})
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "ID", exportName("id"))
+	assert.Equal(t, "RetailCostVAT", exportName("retailCostVAT"))
+	assert.Equal(t, "MeasurementSeries", exportName("measurement-series"))
+	assert.Equal(t, "id", unexportName("id"))
+	assert.Equal(t, "installationFilter", unexportName("installationFilter"))
+	assert.Equal(t, "getMeasurementsResolution", operationName("get", "/measurements/{id}/resolution/{resolution}"))
+	assert.Equal(t, "getInstallationsMeasurementSeries", operationName("get", "/installations/measurement-series"))
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("rejects invalid specs", func(t *testing.T) {
+		_, err := generate([]byte("not json"), options{Package: "eon"})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unsupported parameters", func(t *testing.T) {
+		spec := `{"paths": {"/api/things": {"get": {"parameters": [{"name": "x", "in": "header", "schema": {"type": "string"}}]}}}}`
+
+		_, err := generate([]byte(spec), options{Package: "eon", PathPrefix: "/api"})
+		assert.ErrorContains(t, err, "unsupported location")
+	})
+}
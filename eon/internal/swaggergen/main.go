@@ -0,0 +1,37 @@
+// Command swaggergen generates the models and low-level endpoint functions of the eon package
+// from the OpenAPI spec of the Eon API.
+//
+// Usage (see go:generate in eon/models.go):
+//
+//	go run ./internal/swaggergen -spec ../docs/swagger.json -out .
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "../docs/swagger.json", "OpenAPI spec to generate from")
+	out := flag.String("out", ".", "Directory to write the generated files to")
+	pkg := flag.String("package", "eon", "Package of the generated code")
+	prefix := flag.String("prefix", "/api", "Path prefix included in the client's base URL")
+	flag.Parse()
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := generate(data, options{Package: *pkg, PathPrefix: *prefix})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
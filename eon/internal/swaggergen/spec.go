@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// spec is the subset of an OpenAPI 3 document used by the generator
+type spec struct {
+	Paths      orderedMap[map[string]*operation] `json:"paths"`
+	Components struct {
+		Schemas orderedMap[*schema] `json:"schemas"`
+	} `json:"components"`
+}
+
+// operation is an operation of a path
+type operation struct {
+	Summary    string               `json:"summary"`
+	Parameters []*parameter         `json:"parameters"`
+	Responses  map[string]*response `json:"responses"`
+}
+
+// parameter is a path or query parameter of an operation
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *schema `json:"schema"`
+}
+
+// response is a response of an operation
+type response struct {
+	Description string `json:"description"`
+	Content     map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"content"`
+}
+
+// schema is a schema of a model, property or parameter
+type schema struct {
+	Ref         string              `json:"$ref"`
+	Type        string              `json:"type"`
+	Format      string              `json:"format"`
+	Description string              `json:"description"`
+	Nullable    bool                `json:"nullable"`
+	Items       *schema             `json:"items"`
+	AllOf       []*schema           `json:"allOf"`
+	OneOf       []*schema           `json:"oneOf"`
+	Properties  orderedMap[*schema] `json:"properties"`
+}
+
+// orderedMap is a JSON object keeping the order of its keys, so generated code follows the spec
+type orderedMap[T any] struct {
+	Keys   []string
+	Values map[string]T
+}
+
+func (m *orderedMap[T]) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+
+	m.Keys, m.Values = nil, map[string]T{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+
+		var value T
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		m.Keys = append(m.Keys, key)
+		m.Values[key] = value
+	}
+	return nil
+}
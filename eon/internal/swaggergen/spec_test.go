@@ -0,0 +1,23 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap(t *testing.T) {
+	t.Run("keeps the order of keys", func(t *testing.T) {
+		var m orderedMap[int]
+		assert.NoError(t, json.Unmarshal([]byte(`{"b": 1, "a": 2, "c": 3}`), &m))
+
+		assert.Equal(t, []string{"b", "a", "c"}, m.Keys)
+		assert.Equal(t, 2, m.Values["a"])
+	})
+
+	t.Run("rejects non-objects", func(t *testing.T) {
+		var m orderedMap[int]
+		assert.Error(t, json.Unmarshal([]byte(`[1, 2]`), &m))
+	})
+}
@@ -0,0 +1,127 @@
+package eon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// redacted replaces secret values in logs
+const redacted = "REDACTED"
+
+// WithLogger logs requests of the client to logger at debug level: token refreshes, the method,
+// path and query of requests (including those of the token endpoint), their status, duration and
+// retries. Secrets and tokens are redacted.
+//
+// Example:
+//
+//	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	client := eon.New(eon.WithLogger(logger))
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *client) {
+		c.logger = logger
+
+		// The token client is logged like the API client, so failing token requests show up too
+		for _, r := range []*resty.Client{c.resty, c.tokenClient()} {
+			r.SetLogger(restyLogger{logger})
+			r.AddRetryHook(func(res *resty.Response, err error) {
+				attrs := []any{}
+				if res != nil {
+					attrs = append(attrs, requestAttrs(res.Request)...)
+					attrs = append(attrs, slog.Int("attempt", res.Request.Attempt))
+					if res.RawResponse != nil {
+						attrs = append(attrs, slog.Int("status", res.StatusCode()))
+					}
+				}
+				if err != nil {
+					attrs = append(attrs, slog.String("error", err.Error()))
+				}
+				logger.Debug("retrying request", attrs...)
+			})
+			r.OnSuccess(func(_ *resty.Client, res *resty.Response) {
+				attrs := append(requestAttrs(res.Request),
+					slog.Int("status", res.StatusCode()),
+					slog.Duration("duration", res.Time()),
+					slog.Int("attempts", res.Request.Attempt),
+				)
+				if cache := res.Header().Get("X-Cache"); cache != "" {
+					attrs = append(attrs, slog.String("cache", cache))
+				}
+				logger.Debug("request completed", attrs...)
+			})
+			r.OnError(func(req *resty.Request, err error) {
+				attrs := append(requestAttrs(req),
+					slog.Int("attempts", req.Attempt),
+					slog.String("error", err.Error()),
+				)
+				logger.Debug("request failed", attrs...)
+			})
+		}
+	}
+}
+
+// log returns the logger of the client, discarding logs if none is set
+func (c *client) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return c.logger
+}
+
+// requestAttrs returns the method, path and redacted query of a request
+func requestAttrs(req *resty.Request) []any {
+	path, query := req.URL, url.Values(req.QueryParam)
+	if req.RawRequest != nil {
+		path, query = req.RawRequest.URL.Path, req.RawRequest.URL.Query()
+	}
+	return []any{
+		slog.String("method", req.Method),
+		slog.String("path", path),
+		slog.String("query", redactQuery(query)),
+	}
+}
+
+// redactQuery encodes a query, replacing values of parameters that look like secrets
+func redactQuery(query url.Values) string {
+	safe := url.Values{}
+	for key, values := range query {
+		if isSecret(key) {
+			safe[key] = []string{redacted}
+			continue
+		}
+		safe[key] = values
+	}
+	return safe.Encode()
+}
+
+// isSecret reports whether a parameter or header name holds credentials
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range []string{"secret", "token", "password", "authorization", "api-key", "apikey"} {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// restyLogger routes the messages of resty to a slog logger
+type restyLogger struct {
+	logger *slog.Logger
+}
+
+func (l restyLogger) Errorf(format string, v ...interface{}) {
+	l.logger.Log(context.Background(), slog.LevelError, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l restyLogger) Warnf(format string, v ...interface{}) {
+	l.logger.Log(context.Background(), slog.LevelWarn, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l restyLogger) Debugf(format string, v ...interface{}) {
+	l.logger.Log(context.Background(), slog.LevelDebug, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
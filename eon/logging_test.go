@@ -0,0 +1,95 @@
+package eon
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	mockResty := resty.New()
+	mockAuth := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	httpmock.ActivateNonDefault(mockAuth.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		resty:        mockResty,
+		auth:         mockAuth,
+	}
+	WithLogger(logger)(c)
+	WithRetries(2, time.Millisecond)(c)
+
+	httpmock.RegisterResponder("POST", tokenEndpoint,
+		httpmock.NewJsonResponderOrPanic(200, OAuth2TokenResponse{AccessToken: "secret-access-token", ExpiresIn: 3600}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{}))
+
+	attempts := 0
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return httpmock.NewStringResponse(429, "slow down"), nil
+			}
+			return httpmock.NewJsonResponse(200, InstallationsMeasurementsWrapper{})
+		})
+
+	t.Run("logs token refreshes and requests", func(t *testing.T) {
+		logs.Reset()
+
+		_, err := c.GetInstallations([]string{"inst-1"})
+		assert.NoError(t, err)
+
+		assert.Contains(t, logs.String(), `"msg":"refreshing access token"`)
+		assert.Contains(t, logs.String(), `"msg":"access token refreshed"`)
+		assert.Contains(t, logs.String(), `"msg":"request completed"`)
+		assert.Contains(t, logs.String(), `"method":"GET","path":"/installations","query":"installationFilter=inst-1","status":200`)
+	})
+
+	t.Run("redacts secrets", func(t *testing.T) {
+		assert.NotContains(t, logs.String(), "secret-access-token")
+		assert.NotContains(t, logs.String(), "test-client-secret")
+	})
+
+	t.Run("logs retries", func(t *testing.T) {
+		logs.Reset()
+
+		_, err := c.GetMeasurementSeries()
+		assert.NoError(t, err)
+
+		assert.Contains(t, logs.String(), `"msg":"retrying request"`)
+		assert.Contains(t, logs.String(), `"status":429`)
+		assert.Contains(t, logs.String(), `"attempts":2`)
+	})
+
+	t.Run("logs failing token requests", func(t *testing.T) {
+		logs.Reset()
+		httpmock.RegisterResponder("POST", tokenEndpoint, httpmock.NewStringResponder(401, "invalid client"))
+		c.accessToken = ""
+
+		_, err := c.GetInstallations(nil)
+		assert.Error(t, err)
+
+		assert.Contains(t, logs.String(), `"msg":"request completed","method":"POST"`)
+		assert.Contains(t, logs.String(), `"status":401`)
+	})
+}
+
+func TestRedactQuery(t *testing.T) {
+	query := url.Values{"from": {"2024-01-01"}, "access_token": {"abc"}, "client_secret": {"def"}}
+
+	assert.Equal(t, "access_token=REDACTED&client_secret=REDACTED&from=2024-01-01", redactQuery(query))
+}
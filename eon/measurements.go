@@ -20,36 +20,23 @@ import (
 //	to := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
 //	measurements, err := client.GetMeasurements(12345, eon.Hour, from, to, false)
 func (c *client) GetMeasurements(id int, resolution Resolution, from, to time.Time, includeMissing bool) (MeasurementsWrapper, error) {
-	accessToken, err := c.GetAccessToken()
+	req, err := c.request("GetMeasurements", map[string]interface{}{AttributeSeriesID: id, AttributeResolution: string(resolution)})
 	if err != nil {
 		return MeasurementsWrapper{}, err
 	}
 
-	var result MeasurementsWrapper
-
-	path := fmt.Sprintf("/measurements/%d/resolution/%s", id, resolution)
-
-	req := c.resty.R().
-		SetAuthToken(accessToken).
-		SetResult(&result)
+	params := getMeasurementsResolutionParams{IncludeMissing: &includeMissing}
 
 	// Add time range parameters if provided
 	if !from.IsZero() {
 		// Format as RFC3339 with milliseconds and Z suffix
-		req.SetQueryParam("from", from.Format("2006-01-02T15:04:05.000Z"))
+		params.From = from.Format("2006-01-02T15:04:05.000Z")
 	}
 	if !to.IsZero() {
-		req.SetQueryParam("to", to.Format("2006-01-02T15:04:05.000Z"))
-	}
-
-	// Add includeMissing parameter
-	if includeMissing {
-		req.SetQueryParam("includeMissing", "true")
-	} else {
-		req.SetQueryParam("includeMissing", "false")
+		params.To = to.Format("2006-01-02T15:04:05.000Z")
 	}
 
-	res, err := req.Get(path)
+	result, res, err := getMeasurementsResolution(req, id, string(resolution), params)
 	if err != nil {
 		return MeasurementsWrapper{}, err
 	}
@@ -64,3 +51,48 @@ func (c *client) GetMeasurements(id int, resolution Resolution, from, to time.Ti
 
 	return result, nil
 }
+
+// GetMeasurementsChunked retrieves measurements over ranges longer than the API allows per request
+// (3 months for quarter, 1 year for hour) by splitting the range into consecutive requests and
+// concatenating their measurements. Other resolutions, and open ranges, are fetched in one request.
+//
+// Example:
+//
+//	measurements, err := eon.GetMeasurementsChunked(client, 12345, eon.Quarter, from, to, false)
+func GetMeasurementsChunked(c Client, id int, resolution Resolution, from, to time.Time, includeMissing bool) (MeasurementsWrapper, error) {
+	var step func(time.Time) time.Time
+	switch resolution {
+	case Quarter:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 3, 0) }
+	case Hour:
+		step = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	}
+	if step == nil || from.IsZero() || to.IsZero() || !step(from).Before(to) {
+		return c.GetMeasurements(id, resolution, from, to, includeMissing)
+	}
+
+	result := MeasurementsWrapper{ID: id, Resolution: string(resolution), Measurements: []MeasurementDto{}}
+	seen := map[time.Time]bool{}
+	for start := from; start.Before(to); start = step(start) {
+		// Chunks end just before the next one starts, as the range is inclusive
+		end := step(start).Add(-time.Millisecond)
+		if end.After(to) {
+			end = to
+		}
+
+		chunk, err := c.GetMeasurements(id, resolution, start, end, includeMissing)
+		if err != nil {
+			return MeasurementsWrapper{}, fmt.Errorf("measurements from %s: %w", start.Format(time.DateOnly), err)
+		}
+		if chunk.ID != 0 {
+			result.ID = chunk.ID
+		}
+		for _, m := range chunk.Measurements {
+			if !seen[m.TimeStamp.Time] {
+				seen[m.TimeStamp.Time] = true
+				result.Measurements = append(result.Measurements, m)
+			}
+		}
+	}
+	return result, nil
+}
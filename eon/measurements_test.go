@@ -152,3 +152,60 @@ func TestGetMeasurements(t *testing.T) {
 		}
 	})
 }
+
+func TestGetMeasurementsChunked(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	var ranges [][2]string
+	respond := func(req *http.Request) (*http.Response, error) {
+		from, to := req.URL.Query().Get("from"), req.URL.Query().Get("to")
+		ranges = append(ranges, [2]string{from, to})
+
+		start, _ := time.Parse("2006-01-02T15:04:05.000Z", from)
+		return httpmock.NewJsonResponse(200, MeasurementsWrapper{
+			ID:           12345,
+			Resolution:   "quarter",
+			Measurements: []MeasurementDto{{TimeStamp: FlexibleTime{Time: start}, Value: float(1)}},
+		})
+	}
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/quarter", respond)
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/day", respond)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("splits long quarter ranges", func(t *testing.T) {
+		ranges = nil
+
+		result, err := GetMeasurementsChunked(c, 12345, Quarter, from, to, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]string{
+			{"2024-01-01T00:00:00.000Z", "2024-03-31T23:59:59.999Z"},
+			{"2024-04-01T00:00:00.000Z", "2024-06-30T23:59:59.999Z"},
+			{"2024-07-01T00:00:00.000Z", "2024-08-15T00:00:00.000Z"},
+		}, ranges)
+		assert.Equal(t, 12345, result.ID)
+		assert.Len(t, result.Measurements, 3)
+	})
+
+	t.Run("fetches other resolutions at once", func(t *testing.T) {
+		ranges = nil
+
+		result, err := GetMeasurementsChunked(c, 12345, Day, from, to, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, ranges, 1)
+		assert.Len(t, result.Measurements, 1)
+	})
+}
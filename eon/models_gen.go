@@ -0,0 +1,179 @@
+// Code generated by swaggergen from docs/swagger.json. DO NOT EDIT.
+
+package eon
+
+// CostBioGasDetailsDto is the CostBioGasDetailsDto schema of the API.
+type CostBioGasDetailsDto struct {
+	BioGasCarbonDioxideTax    *float64 `json:"bioGasCarbonDioxideTax"`
+	BioGasCarbonDioxideTaxVAT *float64 `json:"bioGasCarbonDioxideTaxVAT"`
+	BiogasEnergyTax           *float64 `json:"biogasEnergyTax"`
+	BiogasEnergyTaxVAT        *float64 `json:"biogasEnergyTaxVAT"`
+	BiogasAccumulatedTax      *float64 `json:"biogasAccumulatedTax"`
+	BiogasAccumulatedTaxVAT   *float64 `json:"biogasAccumulatedTaxVAT"`
+}
+
+// CostElectricityProductionDto is the CostElectricityProductionDto schema of the API.
+type CostElectricityProductionDto struct {
+	CostsBaseDto
+	RetailCost      *float64            `json:"retailCost"`
+	RetailCostVAT   *float64            `json:"retailCostVAT"`
+	EnergyTax       *float64            `json:"energyTax"`
+	EnergyTaxVAT    *float64            `json:"energyTaxVAT"`
+	NetCost         *float64            `json:"netCost"`
+	NetCostVAT      *float64            `json:"netCostVAT"`
+	CostGridDetails *CostGridDetailsDto `json:"costGridDetails"`
+}
+
+// CostGasDto is the CostGasDto schema of the API.
+type CostGasDto struct {
+	CostsBaseDto
+	RetailCost        *float64              `json:"retailCost"`
+	RetailCostVAT     *float64              `json:"retailCostVAT"`
+	EnergyTax         *float64              `json:"energyTax"`
+	EnergyTaxVAT      *float64              `json:"energyTaxVAT"`
+	CostBioGasDetails *CostBioGasDetailsDto `json:"costBioGasDetails"`
+}
+
+// CostGridDetailsDto is the CostGridDetailsDto schema of the API.
+type CostGridDetailsDto struct {
+	GridSubscription                   *float64 `json:"gridSubscription"`
+	GridSubscriptionVAT                *float64 `json:"gridSubscriptionVAT"`
+	GridSubscribedEffectReactiveIn     *float64 `json:"gridSubscribedEffectReactiveIn"`
+	GridSubscribedEffectReactiveInVAT  *float64 `json:"gridSubscribedEffectReactiveInVAT"`
+	GridSubscribedEffectReactiveOut    *float64 `json:"gridSubscribedEffectReactiveOut"`
+	GridSubscribedEffectReactiveOutVAT *float64 `json:"gridSubscribedEffectReactiveOutVAT"`
+	GridSubscribedEffectWinter         *float64 `json:"gridSubscribedEffectWinter"`
+	GridSubscribedEffectWinterVAT      *float64 `json:"gridSubscribedEffectWinterVAT"`
+	GridSubscribedEffect               *float64 `json:"gridSubscribedEffect"`
+	GridSubscribedEffectVAT            *float64 `json:"gridSubscribedEffectVAT"`
+	GridEffectCompensation             *float64 `json:"gridEffectCompensation"`
+	GridEffectCompensationVAT          *float64 `json:"gridEffectCompensationVAT"`
+	GridEffect                         *float64 `json:"gridEffect"`
+	GridEffectVAT                      *float64 `json:"gridEffectVAT"`
+	GridCompensationEnergy             *float64 `json:"gridCompensationEnergy"`
+	GridCompensationEnergyVAT          *float64 `json:"gridCompensationEnergyVAT"`
+	GridExceededReactiveEffectOut      *float64 `json:"gridExceededReactiveEffectOut"`
+	GridExceededReactiveEffectOutVAT   *float64 `json:"gridExceededReactiveEffectOutVAT"`
+	GridCompensationLoss               *float64 `json:"gridCompensationLoss"`
+	GridCompensationLossVAT            *float64 `json:"gridCompensationLossVAT"`
+	GridOther                          *float64 `json:"gridOther"`
+	GridOtherVAT                       *float64 `json:"gridOtherVAT"`
+	GridExceededActiveEffect           *float64 `json:"gridExceededActiveEffect"`
+	GridExceededActiveEffectVAT        *float64 `json:"gridExceededActiveEffectVAT"`
+	GridFixed                          *float64 `json:"gridFixed"`
+	GridFixedVAT                       *float64 `json:"gridFixedVAT"`
+	GridExceededReactiveEffect         *float64 `json:"gridExceededReactiveEffect"`
+	GridExceededReactiveEffectVAT      *float64 `json:"gridExceededReactiveEffectVAT"`
+	GridTransfer                       *float64 `json:"gridTransfer"`
+	GridTransferVAT                    *float64 `json:"gridTransferVAT"`
+}
+
+// CostHeatColdDto is the CostHeatColdDto schema of the API.
+type CostHeatColdDto struct {
+	CostsBaseDto
+	RetailCost    *float64 `json:"retailCost"`
+	RetailCostVAT *float64 `json:"retailCostVAT"`
+	EffectCost    *float64 `json:"effectCost"`
+	EffectCostVAT *float64 `json:"effectCostVAT"`
+	EnergyCost    *float64 `json:"energyCost"`
+	EnergyCostVAT *float64 `json:"energyCostVAT"`
+	FlowCost      *float64 `json:"flowCost"`
+	FlowCostVAT   *float64 `json:"flowCostVAT"`
+}
+
+// CostsBaseDto is the CostsBaseDto schema of the API.
+type CostsBaseDto struct {
+	Month FlexibleTime `json:"month"`
+}
+
+// CostsColdWrapper is the CostsColdWrapper schema of the API.
+type CostsColdWrapper struct {
+	CostsWrapper
+	Costs []CostHeatColdDto `json:"costs"`
+}
+
+// CostsElectricityWrapper is the CostsElectricityWrapper schema of the API.
+type CostsElectricityWrapper struct {
+	CostsWrapper
+	Costs []CostElectricityProductionDto `json:"costs"`
+}
+
+// CostsGasWrapper is the CostsGasWrapper schema of the API.
+type CostsGasWrapper struct {
+	CostsWrapper
+	Costs []CostGasDto `json:"costs"`
+}
+
+// CostsHeatWrapper is the CostsHeatWrapper schema of the API.
+type CostsHeatWrapper struct {
+	CostsWrapper
+	Costs []CostHeatColdDto `json:"costs"`
+}
+
+// CostsProductionWrapper is the CostsProductionWrapper schema of the API.
+type CostsProductionWrapper struct {
+	CostsWrapper
+	Costs []CostElectricityProductionDto `json:"costs"`
+}
+
+// CostsWrapper is the CostsWrapper schema of the API.
+type CostsWrapper struct {
+	EnergyClass  string `json:"energyClass"`
+	Installation string `json:"installation"`
+}
+
+// InstallationDto is the InstallationDto schema of the API.
+type InstallationDto struct {
+	ID                          string   `json:"id"`
+	Active                      bool     `json:"active"`
+	Address                     string   `json:"address"`
+	Business                    string   `json:"business"`
+	Category                    string   `json:"category"`
+	City                        string   `json:"city"`
+	EnergyClass                 string   `json:"energyClass"`
+	GridArea                    string   `json:"gridArea"`
+	Name                        string   `json:"name"`
+	OrgNumber                   string   `json:"orgNumber"`
+	PriceArea                   string   `json:"priceArea"`
+	Resolution                  string   `json:"resolution"`
+	SafetyLevel                 *float32 `json:"safetyLevel"`
+	HasMeasurementsSubscription bool     `json:"hasMeasurementsSubscription"`
+	HasCostsSubscription        bool     `json:"hasCostsSubscription"`
+}
+
+// InstallationMeasurementsDto is the InstallationMeasurementsDto schema of the API.
+type InstallationMeasurementsDto struct {
+	ID                string                 `json:"id"`
+	MeasurementSeries []MeasurementSeriesDto `json:"measurementSeries"`
+}
+
+// InstallationsMeasurementsWrapper is the InstallationsMeasurementsWrapper schema of the API.
+type InstallationsMeasurementsWrapper struct {
+	Installations []InstallationMeasurementsDto `json:"installations"`
+}
+
+// InstallationsWrapper is the InstallationsWrapper schema of the API.
+type InstallationsWrapper struct {
+	Installations []InstallationDto `json:"installations"`
+}
+
+// MeasurementDto is the MeasurementDto schema of the API.
+type MeasurementDto struct {
+	TimeStamp FlexibleTime `json:"timeStamp"`
+	Value     *float64     `json:"value"`
+}
+
+// MeasurementSeriesDto is the MeasurementSeriesDto schema of the API.
+type MeasurementSeriesDto struct {
+	ID         int          `json:"id"`
+	SeriesType string       `json:"seriesType"`
+	Unit       string       `json:"unit"`
+	LastUpdate FlexibleTime `json:"lastUpdate"`
+}
+
+// MeasurementsWrapper is the MeasurementsWrapper schema of the API.
+type MeasurementsWrapper struct {
+	ID           int              `json:"id"`
+	Resolution   string           `json:"resolution"`
+	Measurements []MeasurementDto `json:"measurements"`
+}
@@ -0,0 +1,307 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NettingOptions configures GetNetting
+type NettingOptions struct {
+	ConsumptionSeriesID int            // Detected with IsConsumptionSeries if zero
+	ProductionSeriesID  int            // Detected with IsProductionSeries if zero
+	CostInstallation    string         // Installation with the production costs, the installation itself if empty
+	NoRevenue           bool           // Skip matching production revenue from costs
+	Location            *time.Location // Time zone of the months, UTC if nil
+}
+
+// NettingInterval holds the production and consumption of a single interval in kWh
+type NettingInterval struct {
+	Time            time.Time `json:"time"`
+	Production      float64   `json:"production"`
+	Consumption     float64   `json:"consumption"`
+	SelfConsumption float64   `json:"selfConsumption"` // Production consumed on site
+	Export          float64   `json:"export"`          // Production exceeding consumption
+	Import          float64   `json:"import"`          // Consumption exceeding production
+}
+
+// NettingPeriod sums the netting of a month or the whole range.
+//
+// SelfSufficiency is the share of consumption covered by own production and SelfConsumptionRatio the
+// share of production consumed on site. Revenue is the production revenue from costs, if matched,
+// and RevenuePerKWh the revenue per exported kWh.
+type NettingPeriod struct {
+	Period               string    `json:"period"`
+	Start                time.Time `json:"start"`
+	Production           float64   `json:"production"`
+	Consumption          float64   `json:"consumption"`
+	SelfConsumption      float64   `json:"selfConsumption"`
+	Export               float64   `json:"export"`
+	Import               float64   `json:"import"`
+	SelfSufficiency      float64   `json:"selfSufficiency"`
+	SelfConsumptionRatio float64   `json:"selfConsumptionRatio"`
+	Revenue              *float64  `json:"revenue"`
+	RevenueInclVAT       *float64  `json:"revenueInclVAT"`
+	RevenuePerKWh        *float64  `json:"revenuePerKWh"`
+}
+
+// NettingReport pairs the production and consumption of a prosumer installation.
+// Unpaired counts intervals where only one of the series has a value; they are left out.
+type NettingReport struct {
+	Installation      string            `json:"installation"`
+	ConsumptionSeries int               `json:"consumptionSeries"`
+	ProductionSeries  int               `json:"productionSeries"`
+	Resolution        string            `json:"resolution"`
+	Intervals         []NettingInterval `json:"intervals"`
+	Months            []NettingPeriod   `json:"months"`
+	Total             NettingPeriod     `json:"total"`
+	Unpaired          int               `json:"unpaired"`
+}
+
+// IsProductionSeries reports whether a measurement series looks like an energy production series,
+// i.e. it is measured in (k/M/G)Wh and its type mentions production.
+func IsProductionSeries(series MeasurementSeriesDto) bool {
+	seriesType := strings.ToLower(series.SeriesType)
+	if !strings.Contains(seriesType, "production") || strings.Contains(seriesType, "reactive") {
+		return false
+	}
+	return strings.HasSuffix(strings.ToUpper(series.Unit), "WH")
+}
+
+// GetNetting fetches the production and consumption series of an installation and nets them
+// per interval and month. Unless opts.NoRevenue is set, monthly production revenue is matched from
+// the costs of the installation (or opts.CostInstallation) when they are production costs.
+// ErrorNotFound is returned if either series can't be found.
+//
+// Example:
+//
+//	report, err := eon.GetNetting(client, "735999163005019944", eon.Hour, from, to, eon.NettingOptions{})
+func GetNetting(c Client, installationID string, resolution Resolution, from, to time.Time, opts NettingOptions) (NettingReport, error) {
+	series, err := c.GetMeasurementSeries()
+	if err != nil {
+		return NettingReport{}, err
+	}
+
+	consumption := MeasurementSeriesDto{ID: opts.ConsumptionSeriesID}
+	production := MeasurementSeriesDto{ID: opts.ProductionSeriesID}
+	for _, inst := range series.Installations {
+		if inst.ID != installationID {
+			continue
+		}
+		for _, ms := range inst.MeasurementSeries {
+			switch {
+			case ms.ID == opts.ConsumptionSeriesID:
+				consumption = ms
+			case ms.ID == opts.ProductionSeriesID:
+				production = ms
+			case opts.ConsumptionSeriesID == 0 && consumption.ID == 0 && IsConsumptionSeries(ms):
+				consumption = ms
+			case opts.ProductionSeriesID == 0 && production.ID == 0 && IsProductionSeries(ms):
+				production = ms
+			}
+		}
+	}
+	if consumption.ID == 0 {
+		return NettingReport{}, fmt.Errorf("consumption series for installation %s: %w", installationID, ErrorNotFound)
+	}
+	if production.ID == 0 {
+		return NettingReport{}, fmt.Errorf("production series for installation %s: %w", installationID, ErrorNotFound)
+	}
+
+	consumed, err := c.GetMeasurements(consumption.ID, resolution, from.UTC(), to.UTC(), false)
+	if err != nil {
+		return NettingReport{}, err
+	}
+	produced, err := c.GetMeasurements(production.ID, resolution, from.UTC(), to.UTC(), false)
+	if err != nil {
+		return NettingReport{}, err
+	}
+	if consumed.Resolution == "" {
+		consumed.Resolution = string(resolution)
+	}
+
+	report, err := Net(consumed, produced, consumption.Unit, production.Unit, opts.Location)
+	if err != nil {
+		return NettingReport{}, err
+	}
+	report.Installation = installationID
+	report.ConsumptionSeries = consumption.ID
+	report.ProductionSeries = production.ID
+
+	if opts.NoRevenue {
+		return report, nil
+	}
+
+	costInstallation := opts.CostInstallation
+	if costInstallation == "" {
+		costInstallation = installationID
+	}
+	costs, err := c.GetCosts(costInstallation, &from, &to)
+	if err != nil {
+		return NettingReport{}, err
+	}
+	if err := report.MatchRevenue(costs); err != nil {
+		return NettingReport{}, err
+	}
+	return report, nil
+}
+
+// Net pairs consumption and production measurements of the same resolution by timestamp.
+//
+// Both series are expected to be gross values: production at the generator and consumption of the
+// site. Per interval, production up to the consumption counts as self-consumption, the rest of the
+// production as export and the rest of the consumption as import.
+func Net(consumption, production MeasurementsWrapper, consumptionUnit, productionUnit string, loc *time.Location) (NettingReport, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if consumption.Resolution != "" && production.Resolution != "" && consumption.Resolution != production.Resolution {
+		return NettingReport{}, fmt.Errorf("netting: resolutions differ: %s and %s", consumption.Resolution, production.Resolution)
+	}
+
+	report := NettingReport{
+		ConsumptionSeries: consumption.ID,
+		ProductionSeries:  production.ID,
+		Resolution:        consumption.Resolution,
+		Intervals:         []NettingInterval{},
+		Months:            []NettingPeriod{},
+		Total:             NettingPeriod{Period: "total"},
+	}
+	if report.Resolution == "" {
+		report.Resolution = production.Resolution
+	}
+
+	produced := map[time.Time]float64{}
+	for _, m := range production.Measurements {
+		if m.Value == nil {
+			continue
+		}
+		value, err := ToKWh(*m.Value, productionUnit)
+		if err != nil {
+			return NettingReport{}, err
+		}
+		produced[m.TimeStamp.UTC()] = value
+	}
+
+	months := map[string]*NettingPeriod{}
+	paired := map[time.Time]bool{}
+	for _, m := range consumption.Measurements {
+		if m.Value == nil {
+			continue
+		}
+		ts := m.TimeStamp.UTC()
+		production, ok := produced[ts]
+		if !ok {
+			report.Unpaired++
+			continue
+		}
+		consumption, err := ToKWh(*m.Value, consumptionUnit)
+		if err != nil {
+			return NettingReport{}, err
+		}
+		paired[ts] = true
+
+		interval := NettingInterval{
+			Time:            m.TimeStamp.Time,
+			Production:      production,
+			Consumption:     consumption,
+			SelfConsumption: min(production, consumption),
+			Export:          max(production-consumption, 0),
+			Import:          max(consumption-production, 0),
+		}
+		report.Intervals = append(report.Intervals, interval)
+
+		local := interval.Time.In(loc)
+		name := local.Format("2006-01")
+		month, ok := months[name]
+		if !ok {
+			month = &NettingPeriod{Period: name, Start: time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)}
+			months[name] = month
+		}
+		for _, target := range []*NettingPeriod{month, &report.Total} {
+			target.Production += interval.Production
+			target.Consumption += interval.Consumption
+			target.SelfConsumption += interval.SelfConsumption
+			target.Export += interval.Export
+			target.Import += interval.Import
+		}
+	}
+	report.Unpaired += len(produced) - len(paired)
+
+	for _, month := range months {
+		month.ratios()
+		report.Months = append(report.Months, *month)
+	}
+	sort.Slice(report.Months, func(i, j int) bool { return report.Months[i].Start.Before(report.Months[j].Start) })
+	if len(report.Months) > 0 {
+		report.Total.Start = report.Months[0].Start
+	}
+	report.Total.ratios()
+
+	return report, nil
+}
+
+// MatchRevenue sets the production revenue of each month from the result of GetCosts. Costs of other
+// energy classes than production are ignored, leaving revenue unset.
+func (r *NettingReport) MatchRevenue(costs interface{}) error {
+	summary, err := SummarizeCosts(costs, PeriodMonth)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(strings.ToLower(summary.EnergyClass), "prod") {
+		return nil
+	}
+
+	revenue := map[string]CostPeriodSummary{}
+	for _, p := range summary.Periods {
+		revenue[p.Period] = p
+	}
+
+	var total, totalInclVAT, export float64
+	var matched bool
+	for i := range r.Months {
+		month := &r.Months[i]
+		p, ok := revenue[month.Period]
+		if !ok {
+			continue
+		}
+		month.setRevenue(p.TotalExclVAT, p.TotalInclVAT)
+
+		total += p.TotalExclVAT
+		totalInclVAT += p.TotalInclVAT
+		export += month.Export
+		matched = true
+	}
+
+	r.Total.Revenue, r.Total.RevenueInclVAT, r.Total.RevenuePerKWh = nil, nil, nil
+	if matched {
+		r.Total.Revenue, r.Total.RevenueInclVAT = &total, &totalInclVAT
+		// Only the export of months with revenue is priced
+		if export != 0 {
+			perKWh := total / export
+			r.Total.RevenuePerKWh = &perKWh
+		}
+	}
+	return nil
+}
+
+// setRevenue sets the revenue of a period and the revenue per exported kWh
+func (p *NettingPeriod) setRevenue(exclVAT, inclVAT float64) {
+	p.Revenue, p.RevenueInclVAT = &exclVAT, &inclVAT
+	p.RevenuePerKWh = nil
+	if p.Export != 0 {
+		perKWh := exclVAT / p.Export
+		p.RevenuePerKWh = &perKWh
+	}
+}
+
+// ratios computes the self-sufficiency and self-consumption ratios of a period
+func (p *NettingPeriod) ratios() {
+	if p.Consumption != 0 {
+		p.SelfSufficiency = p.SelfConsumption / p.Consumption
+	}
+	if p.Production != 0 {
+		p.SelfConsumptionRatio = p.SelfConsumption / p.Production
+	}
+}
@@ -0,0 +1,168 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProductionSeries(t *testing.T) {
+	assert.True(t, IsProductionSeries(MeasurementSeriesDto{SeriesType: "ElectricActiveProduction", Unit: "KWH"}))
+	assert.False(t, IsProductionSeries(MeasurementSeriesDto{SeriesType: "ElectricActive", Unit: "KWH"}))
+	assert.False(t, IsProductionSeries(MeasurementSeriesDto{SeriesType: "ElectricReactiveProduction", Unit: "KVARH"}))
+}
+
+func TestNet(t *testing.T) {
+	from := time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC)
+	consumption := hourlySeries(from, float(2), float(3), float(1), float(4))
+	production := hourlySeries(from, float(0), float(5000), float(1000), nil)
+	production.ID = 54321
+
+	report, err := Net(consumption, production, "KWH", "WH", time.UTC)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 54321, report.ProductionSeries)
+	assert.Len(t, report.Intervals, 3)
+	assert.Equal(t, 1, report.Unpaired)
+
+	assert.Equal(t, NettingInterval{Time: from.Add(time.Hour), Production: 5, Consumption: 3, SelfConsumption: 3, Export: 2}, report.Intervals[1])
+	assert.Equal(t, 2.0, report.Intervals[0].Import)
+
+	t.Run("sums months", func(t *testing.T) {
+		assert.Len(t, report.Months, 2)
+		assert.Equal(t, "2024-03", report.Months[0].Period)
+		assert.Equal(t, 5.0, report.Months[0].Consumption)
+
+		assert.Equal(t, 6.0, report.Total.Production)
+		assert.Equal(t, 6.0, report.Total.Consumption)
+		assert.Equal(t, 4.0, report.Total.SelfConsumption)
+		assert.Equal(t, 2.0, report.Total.Export)
+		assert.Equal(t, 2.0, report.Total.Import)
+		assert.InDelta(t, 0.667, report.Total.SelfSufficiency, 0.001)
+		assert.InDelta(t, 0.667, report.Total.SelfConsumptionRatio, 0.001)
+		assert.Nil(t, report.Total.Revenue)
+	})
+
+	t.Run("buckets months in the location", func(t *testing.T) {
+		stockholm, _ := time.LoadLocation("Europe/Stockholm")
+		report, err := Net(consumption, production, "KWH", "WH", stockholm)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Months, 1)
+		assert.Equal(t, "2024-04", report.Months[0].Period)
+	})
+
+	t.Run("rejects mixed resolutions", func(t *testing.T) {
+		daily := production
+		daily.Resolution = "day"
+
+		_, err := Net(consumption, daily, "KWH", "KWH", nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestMatchRevenue(t *testing.T) {
+	report := NettingReport{
+		Months: []NettingPeriod{
+			{Period: "2024-03", Export: 100},
+			{Period: "2024-04", Export: 50},
+		},
+		Total: NettingPeriod{Period: "total", Export: 150},
+	}
+
+	t.Run("ignores consumption costs", func(t *testing.T) {
+		err := report.MatchRevenue(CostsElectricityWrapper{
+			CostsWrapper: CostsWrapper{EnergyClass: "El"},
+			Costs:        []CostElectricityProductionDto{{CostsBaseDto: CostsBaseDto{Month: FlexibleTime{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}}, RetailCost: float(80)}},
+		})
+
+		assert.NoError(t, err)
+		assert.Nil(t, report.Months[0].Revenue)
+	})
+
+	t.Run("matches production revenue per month", func(t *testing.T) {
+		err := report.MatchRevenue(CostsProductionWrapper{
+			CostsWrapper: CostsWrapper{EnergyClass: "Production"},
+			Costs: []CostElectricityProductionDto{
+				{CostsBaseDto: CostsBaseDto{Month: FlexibleTime{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}}, RetailCost: float(80), RetailCostVAT: float(100)},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 80.0, *report.Months[0].Revenue)
+		assert.Equal(t, 100.0, *report.Months[0].RevenueInclVAT)
+		assert.Equal(t, 0.8, *report.Months[0].RevenuePerKWh)
+		assert.Nil(t, report.Months[1].Revenue)
+		assert.Equal(t, 80.0, *report.Total.Revenue)
+		assert.Equal(t, 0.8, *report.Total.RevenuePerKWh)
+	})
+}
+
+func TestGetNetting(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{
+					{ID: 1, SeriesType: "ElectricActiveProduction", Unit: "KWH"},
+					{ID: 2, SeriesType: "ElectricActive", Unit: "KWH"},
+				}},
+				{ID: "inst-2", MeasurementSeries: []MeasurementSeriesDto{{ID: 3, SeriesType: "ElectricActive", Unit: "KWH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, hourlySeries(from, float(4), float(1))))
+	httpmock.RegisterResponder("GET", "/measurements/2/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, hourlySeries(from, float(1), float(2))))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"energyClass":  "Produktion",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{"month": "2024-03-01T00:00:00", "retailCost": 1.5, "retailCostVAT": 1.5},
+			},
+		}))
+
+	t.Run("nets detected series", func(t *testing.T) {
+		report, err := GetNetting(c, "inst-1", Hour, from, to, NettingOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "inst-1", report.Installation)
+		assert.Equal(t, 2, report.ConsumptionSeries)
+		assert.Equal(t, 1, report.ProductionSeries)
+		assert.Equal(t, 3.0, report.Total.Export)
+		assert.Equal(t, 1.0, report.Total.Import)
+		assert.Equal(t, 0.5, *report.Total.RevenuePerKWh)
+	})
+
+	t.Run("skips revenue", func(t *testing.T) {
+		report, err := GetNetting(c, "inst-1", Hour, from, to, NettingOptions{NoRevenue: true})
+
+		assert.NoError(t, err)
+		assert.Nil(t, report.Total.Revenue)
+	})
+
+	t.Run("requires a production series", func(t *testing.T) {
+		_, err := GetNetting(c, "inst-2", Hour, from, to, NettingOptions{})
+
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+}
@@ -0,0 +1,268 @@
+package eon
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// minNormalizeDays is the minimum number of days with both consumption and temperature to fit a WeatherModel
+const minNormalizeDays = 7
+
+// NormalizeOptions configures Normalize
+type NormalizeOptions struct {
+	DegreeDays DegreeDayOptions // Base temperatures of the degree-days
+	Normals    []Temperature    // Normal daily temperatures of any year, by default the mean of each calendar day of the temperatures
+	Location   *time.Location   // Time zone of days and months, UTC if nil
+}
+
+// WeatherModel is the linear dependence of daily consumption on degree-days fitted by least squares:
+// consumption = Base + Heating × heating degree-days + Cooling × cooling degree-days.
+// R2 is the share of variance explained by the model.
+type WeatherModel struct {
+	Base    float64 `json:"base"`    // Weather-independent consumption per day
+	Heating float64 `json:"heating"` // Consumption per heating degree-day
+	Cooling float64 `json:"cooling"` // Consumption per cooling degree-day
+	R2      float64 `json:"r2"`
+	Days    int     `json:"days"`
+}
+
+// NormalizedDay holds the actual and weather-normalised consumption of a day
+type NormalizedDay struct {
+	Date          time.Time `json:"date"`
+	Consumption   float64   `json:"consumption"`
+	Normalized    float64   `json:"normalized"`
+	Temperature   float64   `json:"temperature"`
+	Heating       float64   `json:"heating"` // Heating degree-days
+	Cooling       float64   `json:"cooling"` // Cooling degree-days
+	NormalHeating float64   `json:"normalHeating"`
+	NormalCooling float64   `json:"normalCooling"`
+}
+
+// NormalizedPeriod sums the days of a month or the whole range
+type NormalizedPeriod struct {
+	Period        string    `json:"period"`
+	Start         time.Time `json:"start"`
+	Days          int       `json:"days"`
+	Consumption   float64   `json:"consumption"`
+	Normalized    float64   `json:"normalized"`
+	Heating       float64   `json:"heating"`
+	Cooling       float64   `json:"cooling"`
+	NormalHeating float64   `json:"normalHeating"`
+	NormalCooling float64   `json:"normalCooling"`
+}
+
+// NormalizationReport holds the weather-normalised consumption of a series per day and month.
+// Consumption is in the unit of the series. Unmatched counts days with consumption but no temperature
+// or normal; they are left out.
+type NormalizationReport struct {
+	SeriesID  int                `json:"seriesId"`
+	Unit      string             `json:"unit"`
+	Model     WeatherModel       `json:"model"`
+	Days      []NormalizedDay    `json:"days"`
+	Months    []NormalizedPeriod `json:"months"`
+	Total     NormalizedPeriod   `json:"total"`
+	Unmatched int                `json:"unmatched"`
+}
+
+// GetNormalized fetches the daily consumption of a series over [from, to] and normalises it to
+// normal weather with the given daily outdoor temperatures (see Normalize)
+//
+// Example:
+//
+//	temperatures, _ := eon.LoadTemperatureFile("temperatures.csv", stockholm)
+//	report, err := eon.GetNormalized(client, 737605, from, to, temperatures, eon.NormalizeOptions{Location: stockholm})
+func GetNormalized(c Client, seriesID int, from, to time.Time, temperatures []Temperature, opts NormalizeOptions) (NormalizationReport, error) {
+	meta, err := GetSeriesMetadata(c, seriesID)
+	if err != nil {
+		return NormalizationReport{}, err
+	}
+
+	measurements, err := c.GetMeasurements(seriesID, Day, from.UTC(), to.UTC(), false)
+	if err != nil {
+		return NormalizationReport{}, err
+	}
+
+	report, err := Normalize(measurements, temperatures, opts)
+	if err != nil {
+		return NormalizationReport{}, err
+	}
+	report.SeriesID = seriesID
+	report.Unit = meta.Series.Unit
+	return report, nil
+}
+
+// Normalize fits a WeatherModel to daily measurements and degree-days, and corrects the consumption of
+// each day for the difference between its degree-days and the normal degree-days of the calendar day:
+// normalized = consumption - Heating × (heating - normal heating) - Cooling × (cooling - normal cooling).
+//
+// Without opts.Normals, the normal degree-days of a calendar day are the average over every year of the
+// temperatures, so several years of temperatures give the most meaningful normals.
+func Normalize(m MeasurementsWrapper, temperatures []Temperature, opts NormalizeOptions) (NormalizationReport, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// Degree-days per date and normal degree-days per calendar day
+	actual := map[string]DegreeDay{}
+	for _, d := range DegreeDays(temperatures, opts.DegreeDays) {
+		actual[d.Date.In(loc).Format(time.DateOnly)] = d
+	}
+	normalSource := temperatures
+	if len(opts.Normals) > 0 {
+		normalSource = opts.Normals
+	}
+	normals := normalDegreeDays(DegreeDays(normalSource, opts.DegreeDays), loc)
+
+	report := NormalizationReport{
+		SeriesID: m.ID,
+		Days:     []NormalizedDay{},
+		Months:   []NormalizedPeriod{},
+		Total:    NormalizedPeriod{Period: "total"},
+	}
+
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		// Daily values are UTC midnight; their date must not shift a day west of UTC
+		date := measurement.TimeStamp.Time
+		d, ok := actual[date.Format(time.DateOnly)]
+		normal, hasNormal := normalOf(normals, date)
+		if !ok || !hasNormal {
+			report.Unmatched++
+			continue
+		}
+
+		report.Days = append(report.Days, NormalizedDay{
+			Date:          time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc),
+			Consumption:   *measurement.Value,
+			Temperature:   d.Temperature,
+			Heating:       d.Heating,
+			Cooling:       d.Cooling,
+			NormalHeating: normal.Heating,
+			NormalCooling: normal.Cooling,
+		})
+	}
+	if len(report.Days) < minNormalizeDays {
+		return NormalizationReport{}, fmt.Errorf("normalize: %d days with consumption and temperature, at least %d needed", len(report.Days), minNormalizeDays)
+	}
+	sort.Slice(report.Days, func(i, j int) bool { return report.Days[i].Date.Before(report.Days[j].Date) })
+
+	report.Model = fitWeatherModel(report.Days)
+
+	months := map[string]*NormalizedPeriod{}
+	for i := range report.Days {
+		d := &report.Days[i]
+		d.Normalized = d.Consumption - report.Model.Heating*(d.Heating-d.NormalHeating) - report.Model.Cooling*(d.Cooling-d.NormalCooling)
+
+		name := d.Date.Format("2006-01")
+		month, ok := months[name]
+		if !ok {
+			month = &NormalizedPeriod{Period: name, Start: time.Date(d.Date.Year(), d.Date.Month(), 1, 0, 0, 0, 0, loc)}
+			months[name] = month
+		}
+		for _, target := range []*NormalizedPeriod{month, &report.Total} {
+			target.Days++
+			target.Consumption += d.Consumption
+			target.Normalized += d.Normalized
+			target.Heating += d.Heating
+			target.Cooling += d.Cooling
+			target.NormalHeating += d.NormalHeating
+			target.NormalCooling += d.NormalCooling
+		}
+	}
+
+	for _, month := range months {
+		report.Months = append(report.Months, *month)
+	}
+	sort.Slice(report.Months, func(i, j int) bool { return report.Months[i].Start.Before(report.Months[j].Start) })
+	report.Total.Start = report.Months[0].Start
+
+	return report, nil
+}
+
+// normalDegreeDays averages degree-days per calendar day ("01-02")
+func normalDegreeDays(days []DegreeDay, loc *time.Location) map[string]DegreeDay {
+	type sum struct {
+		heating, cooling float64
+		count            int
+	}
+	sums := map[string]*sum{}
+	for _, d := range days {
+		key := d.Date.In(loc).Format("01-02")
+		s, ok := sums[key]
+		if !ok {
+			s = &sum{}
+			sums[key] = s
+		}
+		s.heating += d.Heating
+		s.cooling += d.Cooling
+		s.count++
+	}
+
+	normals := map[string]DegreeDay{}
+	for key, s := range sums {
+		normals[key] = DegreeDay{Heating: s.heating / float64(s.count), Cooling: s.cooling / float64(s.count)}
+	}
+	return normals
+}
+
+// normalOf returns the normal degree-days of the calendar day of t, using February 28 for leap days without normals
+func normalOf(normals map[string]DegreeDay, t time.Time) (DegreeDay, bool) {
+	key := t.Format("01-02")
+	normal, ok := normals[key]
+	if !ok && key == "02-29" {
+		normal, ok = normals["02-28"]
+	}
+	return normal, ok
+}
+
+// fitWeatherModel fits consumption against heating and cooling degree-days by least squares.
+// Degree-days without variance, e.g. cooling degree-days in winter, are left out of the fit.
+func fitWeatherModel(days []NormalizedDay) WeatherModel {
+	n := float64(len(days))
+	var meanH, meanC, meanY float64
+	for _, d := range days {
+		meanH += d.Heating / n
+		meanC += d.Cooling / n
+		meanY += d.Consumption / n
+	}
+
+	var shh, scc, shc, shy, scy, syy float64
+	for _, d := range days {
+		h, c, y := d.Heating-meanH, d.Cooling-meanC, d.Consumption-meanY
+		shh += h * h
+		scc += c * c
+		shc += h * c
+		shy += h * y
+		scy += c * y
+		syy += y * y
+	}
+
+	const eps = 1e-9
+	model := WeatherModel{Days: len(days)}
+	det := shh*scc - shc*shc
+	switch {
+	case shh > eps && scc > eps && math.Abs(det) > eps:
+		model.Heating = (shy*scc - scy*shc) / det
+		model.Cooling = (scy*shh - shy*shc) / det
+	case shh > eps:
+		model.Heating = shy / shh
+	case scc > eps:
+		model.Cooling = scy / scc
+	}
+	model.Base = meanY - model.Heating*meanH - model.Cooling*meanC
+
+	if syy > 0 {
+		var residuals float64
+		for _, d := range days {
+			r := d.Consumption - model.Base - model.Heating*d.Heating - model.Cooling*d.Cooling
+			residuals += r * r
+		}
+		model.R2 = 1 - residuals/syy
+	}
+	return model
+}
@@ -0,0 +1,142 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// weatherSeries returns daily temperatures and a consumption of 10 + 2 per heating degree-day
+func weatherSeries(from time.Time, temperatures ...float64) ([]Temperature, MeasurementsWrapper) {
+	var temps []Temperature
+	var values []float64
+	for i, t := range temperatures {
+		temps = append(temps, Temperature{Date: from.AddDate(0, 0, i), Mean: t})
+		values = append(values, 10+2*max(DefaultHeatingBase-t, 0))
+	}
+	return temps, dailySeries(from, values...)
+}
+
+func TestNormalize(t *testing.T) {
+	from := time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)
+	temperatures, m := weatherSeries(from, -5, 0, 5, 10, -10, 2, 7, 12)
+
+	t.Run("fits the weather dependence", func(t *testing.T) {
+		report, err := Normalize(m, temperatures, NormalizeOptions{})
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 10, report.Model.Base, 1e-9)
+		assert.InDelta(t, 2, report.Model.Heating, 1e-9)
+		assert.Equal(t, 0.0, report.Model.Cooling)
+		assert.InDelta(t, 1, report.Model.R2, 1e-9)
+		assert.Equal(t, 8, report.Model.Days)
+
+		// Normals from a single year are the year itself
+		assert.InDelta(t, report.Total.Consumption, report.Total.Normalized, 1e-9)
+	})
+
+	t.Run("normalizes to normal temperatures", func(t *testing.T) {
+		var normals []Temperature
+		for _, temp := range temperatures {
+			normals = append(normals, Temperature{Date: temp.Date.AddDate(-30, 0, 0), Mean: 7})
+		}
+
+		report, err := Normalize(m, temperatures, NormalizeOptions{Normals: normals})
+
+		assert.NoError(t, err)
+		for _, d := range report.Days {
+			assert.InDelta(t, 30, d.Normalized, 1e-9)
+			assert.Equal(t, 10.0, d.NormalHeating)
+		}
+
+		assert.Len(t, report.Months, 2)
+		assert.Equal(t, "2024-01", report.Months[0].Period)
+		assert.Equal(t, 4, report.Months[0].Days)
+		assert.InDelta(t, 120, report.Months[0].Normalized, 1e-9)
+		assert.InDelta(t, 240, report.Total.Normalized, 1e-9)
+	})
+
+	t.Run("uses the configured base temperature", func(t *testing.T) {
+		report, err := Normalize(m, temperatures, NormalizeOptions{DegreeDays: DegreeDayOptions{HeatingBase: 20}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 25.0, report.Days[0].Heating)
+	})
+
+	t.Run("matches UTC days with local temperatures west of UTC", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		assert.NoError(t, err)
+		var local []Temperature
+		for _, temp := range temperatures {
+			local = append(local, Temperature{Date: time.Date(temp.Date.Year(), temp.Date.Month(), temp.Date.Day(), 0, 0, 0, 0, newYork), Mean: temp.Mean})
+		}
+
+		report, err := Normalize(m, local, NormalizeOptions{Location: newYork})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Unmatched)
+		assert.InDelta(t, 1, report.Model.R2, 1e-9)
+		assert.Equal(t, time.Date(2024, 1, 28, 0, 0, 0, 0, newYork), report.Days[0].Date)
+	})
+
+	t.Run("requires enough days with temperatures", func(t *testing.T) {
+		report, err := Normalize(m, temperatures[:3], NormalizeOptions{})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, report.Unmatched)
+	})
+}
+
+func TestFitWeatherModel(t *testing.T) {
+	days := []NormalizedDay{
+		{Consumption: 5, Cooling: 0},
+		{Consumption: 8, Cooling: 1},
+		{Consumption: 11, Cooling: 2},
+	}
+
+	model := fitWeatherModel(days)
+
+	assert.InDelta(t, 5, model.Base, 1e-9)
+	assert.Equal(t, 0.0, model.Heating)
+	assert.InDelta(t, 3, model.Cooling, 1e-9)
+}
+
+func TestGetNormalized(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	temperatures, m := weatherSeries(from, -5, 0, 5, 10, -10, 2, 7, 12, 3)
+	m.Measurements[8].Value = nil
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 12345, SeriesType: "HeatEnergy", Unit: "MWH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{Installations: []InstallationDto{{ID: "inst-1"}}}))
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/day",
+		httpmock.NewJsonResponderOrPanic(200, m))
+
+	report, err := GetNormalized(c, 12345, from, from.AddDate(0, 0, 8), temperatures, NormalizeOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12345, report.SeriesID)
+	assert.Equal(t, "MWH", report.Unit)
+	assert.Len(t, report.Days, 8)
+	assert.InDelta(t, 2, report.Model.Heating, 1e-9)
+}
@@ -0,0 +1,119 @@
+package eon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Notifier delivers alerts raised by Watcher
+type Notifier interface {
+	Notify(alert Alert) error
+}
+
+// WebhookNotifier posts each alert as JSON to a URL
+type WebhookNotifier struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"` // Extra request headers, e.g. for authentication
+}
+
+// Notify posts the alert and fails on non-2xx responses
+func (n WebhookNotifier) Notify(alert Alert) error {
+	resp, err := resty.New().
+		SetTimeout(30 * time.Second).
+		R().
+		SetHeaders(n.Headers).
+		SetBody(alert).
+		Post(n.URL)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	if resp.IsError() {
+		return fmt.Errorf("webhook failed: %s (status %d)", resp.String(), resp.StatusCode())
+	}
+	return nil
+}
+
+// EmailNotifier sends each alert as a plain-text email through an SMTP server.
+// Plain authentication is used when Username is set.
+type EmailNotifier struct {
+	Addr     string   `json:"addr"` // SMTP server as host:port
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// sendMail is replaced in tests
+var sendMail = smtp.SendMail
+
+// Notify sends the alert to every recipient
+func (n EmailNotifier) Notify(alert Alert) error {
+	var auth smtp.Auth
+	if n.Username != "" {
+		host, _, err := net.SplitHostPort(n.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+
+	if err := sendMail(n.Addr, auth, n.From, n.To, n.message(alert)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// message formats the alert as an RFC 5322 message
+func (n EmailNotifier) message(alert Alert) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&b, "Subject: [eon] %s: %s\r\n", alert.Rule, alert.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "%s\r\n\r\nRule: %s\r\nValue: %.2f\r\nThreshold: %.2f\r\nTime: %s\r\n",
+		alert.Message, alert.Rule, alert.Value, alert.Threshold, alert.Time.Format(time.RFC3339))
+	return b.Bytes()
+}
+
+// CommandNotifier runs a command for each alert. The alert is written as JSON to its standard
+// input and its fields are set in the EON_ALERT_* environment variables.
+type CommandNotifier struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// Notify runs the command and fails if it exits with a non-zero code
+func (n CommandNotifier) Notify(alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(n.Command, n.Args...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"EON_ALERT_KEY="+alert.Key,
+		"EON_ALERT_RULE="+alert.Rule,
+		"EON_ALERT_TYPE="+string(alert.Type),
+		"EON_ALERT_SUBJECT="+alert.Subject,
+		"EON_ALERT_TIME="+alert.Time.Format(time.RFC3339),
+		fmt.Sprintf("EON_ALERT_VALUE=%g", alert.Value),
+		fmt.Sprintf("EON_ALERT_THRESHOLD=%g", alert.Threshold),
+		"EON_ALERT_MESSAGE="+alert.Message,
+	)
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("command %s failed: %w: %s", n.Command, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package eon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testAlert = Alert{
+	Key:       "consumption-1/2024-03-15T11:00:00Z",
+	Rule:      "consumption-1",
+	Type:      RuleConsumption,
+	Subject:   "1",
+	Time:      time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC),
+	Value:     6,
+	Threshold: 5,
+	Message:   "series 1 consumed 6.00 kWh",
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Run("posts alert as JSON", func(t *testing.T) {
+		var received Alert
+		var auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		n := WebhookNotifier{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}}
+
+		assert.NoError(t, n.Notify(testAlert))
+		assert.Equal(t, testAlert.Key, received.Key)
+		assert.Equal(t, "Bearer secret", auth)
+	})
+
+	t.Run("fails on error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		assert.Error(t, WebhookNotifier{URL: server.URL}.Notify(testAlert))
+	})
+}
+
+func TestEmailNotifier(t *testing.T) {
+	original := sendMail
+	defer func() { sendMail = original }()
+
+	var addr, from string
+	var to []string
+	var auth smtp.Auth
+	var msg []byte
+	sendMail = func(a string, au smtp.Auth, f string, t []string, m []byte) error {
+		addr, auth, from, to, msg = a, au, f, t, m
+		return nil
+	}
+
+	n := EmailNotifier{Addr: "smtp.example.com:587", Username: "user", Password: "pass", From: "eon@example.com", To: []string{"ops@example.com"}}
+
+	assert.NoError(t, n.Notify(testAlert))
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.NotNil(t, auth)
+	assert.Equal(t, "eon@example.com", from)
+	assert.Equal(t, []string{"ops@example.com"}, to)
+	assert.Contains(t, string(msg), "Subject: [eon] consumption-1: 1\r\n")
+	assert.Contains(t, string(msg), testAlert.Message)
+
+	n.Username = ""
+	assert.NoError(t, n.Notify(testAlert))
+	assert.Nil(t, auth)
+}
+
+func TestCommandNotifier(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "alert")
+
+	n := CommandNotifier{Command: "sh", Args: []string{"-c", `cat > "$0" && echo "$EON_ALERT_RULE" >> "$0"`, out}}
+
+	assert.NoError(t, n.Notify(testAlert))
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"key":"consumption-1/2024-03-15T11:00:00Z"`)
+	assert.Contains(t, string(data), "}consumption-1\n")
+
+	err = CommandNotifier{Command: "sh", Args: []string{"-c", "echo broken >&2; exit 3"}}.Notify(testAlert)
+	assert.ErrorContains(t, err, "broken")
+}
@@ -0,0 +1,52 @@
+package eon
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPISchemas(t *testing.T) {
+	schemas := openAPISchemas{}
+
+	ref := schemas.schema(reflect.TypeOf(MeasurementsWrapper{}))
+
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/MeasurementsWrapper"}, ref)
+	assert.Contains(t, schemas, "MeasurementDto")
+
+	measurement := schemas["MeasurementDto"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, measurement["timeStamp"])
+	assert.Equal(t, map[string]interface{}{"type": "number", "nullable": true}, measurement["value"])
+
+	t.Run("flattens embedded structs", func(t *testing.T) {
+		schemas.schema(reflect.TypeOf(CostRow{}))
+
+		properties := schemas["CostRow"].(map[string]interface{})["properties"].(map[string]interface{})
+		assert.Contains(t, properties, "gridFixed")
+		assert.Contains(t, properties, "retailCost")
+		assert.NotContains(t, properties, "CostGridDetailsDto")
+	})
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc := OpenAPI()
+
+	// The document must be valid JSON
+	_, err := json.Marshal(doc)
+	assert.NoError(t, err)
+
+	paths := doc["paths"].(map[string]interface{})
+	for _, route := range serverRoutes {
+		assert.Contains(t, paths, route.path)
+	}
+
+	measurements := paths["/measurements/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Len(t, measurements["parameters"], 5)
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Contains(t, schemas, "InstallationDto")
+	assert.Contains(t, schemas, "CostRowsWrapper")
+	assert.Contains(t, schemas, "ServerError")
+}
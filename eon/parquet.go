@@ -0,0 +1,248 @@
+package eon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// MeasurementRow is a flat, columnar representation of a single measurement
+type MeasurementRow struct {
+	InstallationID string    `json:"installationId" parquet:"installationId"`
+	SeriesID       int64     `json:"seriesId" parquet:"seriesId"`
+	SeriesType     string    `json:"seriesType" parquet:"seriesType"`
+	Unit           string    `json:"unit" parquet:"unit"`
+	Resolution     string    `json:"resolution" parquet:"resolution"`
+	TimeStamp      time.Time `json:"timeStamp" parquet:"timeStamp,timestamp(millisecond)"`
+	Value          *float64  `json:"value" parquet:"value"`
+}
+
+// CostRow is a flat, columnar representation of a single month of costs, with the grid and
+// biogas details expanded into columns. Fields not present for the installation's energy class
+// are left nil.
+type CostRow struct {
+	Installation                       string    `json:"installation" parquet:"installation"`
+	EnergyClass                        string    `json:"energyClass" parquet:"energyClass"`
+	Month                              time.Time `json:"month" parquet:"month,timestamp(millisecond)"`
+	RetailCost                         *float64  `json:"retailCost" parquet:"retailCost"`
+	RetailCostVAT                      *float64  `json:"retailCostVAT" parquet:"retailCostVAT"`
+	EnergyTax                          *float64  `json:"energyTax" parquet:"energyTax"`
+	EnergyTaxVAT                       *float64  `json:"energyTaxVAT" parquet:"energyTaxVAT"`
+	NetCost                            *float64  `json:"netCost" parquet:"netCost"`
+	NetCostVAT                         *float64  `json:"netCostVAT" parquet:"netCostVAT"`
+	EffectCost                         *float64  `json:"effectCost" parquet:"effectCost"`
+	EffectCostVAT                      *float64  `json:"effectCostVAT" parquet:"effectCostVAT"`
+	EnergyCost                         *float64  `json:"energyCost" parquet:"energyCost"`
+	EnergyCostVAT                      *float64  `json:"energyCostVAT" parquet:"energyCostVAT"`
+	FlowCost                           *float64  `json:"flowCost" parquet:"flowCost"`
+	FlowCostVAT                        *float64  `json:"flowCostVAT" parquet:"flowCostVAT"`
+	GridSubscription                   *float64  `json:"gridSubscription" parquet:"gridSubscription"`
+	GridSubscriptionVAT                *float64  `json:"gridSubscriptionVAT" parquet:"gridSubscriptionVAT"`
+	GridSubscribedEffectReactiveIn     *float64  `json:"gridSubscribedEffectReactiveIn" parquet:"gridSubscribedEffectReactiveIn"`
+	GridSubscribedEffectReactiveInVAT  *float64  `json:"gridSubscribedEffectReactiveInVAT" parquet:"gridSubscribedEffectReactiveInVAT"`
+	GridSubscribedEffectReactiveOut    *float64  `json:"gridSubscribedEffectReactiveOut" parquet:"gridSubscribedEffectReactiveOut"`
+	GridSubscribedEffectReactiveOutVAT *float64  `json:"gridSubscribedEffectReactiveOutVAT" parquet:"gridSubscribedEffectReactiveOutVAT"`
+	GridSubscribedEffectWinter         *float64  `json:"gridSubscribedEffectWinter" parquet:"gridSubscribedEffectWinter"`
+	GridSubscribedEffectWinterVAT      *float64  `json:"gridSubscribedEffectWinterVAT" parquet:"gridSubscribedEffectWinterVAT"`
+	GridSubscribedEffect               *float64  `json:"gridSubscribedEffect" parquet:"gridSubscribedEffect"`
+	GridSubscribedEffectVAT            *float64  `json:"gridSubscribedEffectVAT" parquet:"gridSubscribedEffectVAT"`
+	GridEffectCompensation             *float64  `json:"gridEffectCompensation" parquet:"gridEffectCompensation"`
+	GridEffectCompensationVAT          *float64  `json:"gridEffectCompensationVAT" parquet:"gridEffectCompensationVAT"`
+	GridEffect                         *float64  `json:"gridEffect" parquet:"gridEffect"`
+	GridEffectVAT                      *float64  `json:"gridEffectVAT" parquet:"gridEffectVAT"`
+	GridCompensationEnergy             *float64  `json:"gridCompensationEnergy" parquet:"gridCompensationEnergy"`
+	GridCompensationEnergyVAT          *float64  `json:"gridCompensationEnergyVAT" parquet:"gridCompensationEnergyVAT"`
+	GridExceededReactiveEffectOut      *float64  `json:"gridExceededReactiveEffectOut" parquet:"gridExceededReactiveEffectOut"`
+	GridExceededReactiveEffectOutVAT   *float64  `json:"gridExceededReactiveEffectOutVAT" parquet:"gridExceededReactiveEffectOutVAT"`
+	GridCompensationLoss               *float64  `json:"gridCompensationLoss" parquet:"gridCompensationLoss"`
+	GridCompensationLossVAT            *float64  `json:"gridCompensationLossVAT" parquet:"gridCompensationLossVAT"`
+	GridOther                          *float64  `json:"gridOther" parquet:"gridOther"`
+	GridOtherVAT                       *float64  `json:"gridOtherVAT" parquet:"gridOtherVAT"`
+	GridExceededActiveEffect           *float64  `json:"gridExceededActiveEffect" parquet:"gridExceededActiveEffect"`
+	GridExceededActiveEffectVAT        *float64  `json:"gridExceededActiveEffectVAT" parquet:"gridExceededActiveEffectVAT"`
+	GridFixed                          *float64  `json:"gridFixed" parquet:"gridFixed"`
+	GridFixedVAT                       *float64  `json:"gridFixedVAT" parquet:"gridFixedVAT"`
+	GridExceededReactiveEffect         *float64  `json:"gridExceededReactiveEffect" parquet:"gridExceededReactiveEffect"`
+	GridExceededReactiveEffectVAT      *float64  `json:"gridExceededReactiveEffectVAT" parquet:"gridExceededReactiveEffectVAT"`
+	GridTransfer                       *float64  `json:"gridTransfer" parquet:"gridTransfer"`
+	GridTransferVAT                    *float64  `json:"gridTransferVAT" parquet:"gridTransferVAT"`
+	BioGasCarbonDioxideTax             *float64  `json:"bioGasCarbonDioxideTax" parquet:"bioGasCarbonDioxideTax"`
+	BioGasCarbonDioxideTaxVAT          *float64  `json:"bioGasCarbonDioxideTaxVAT" parquet:"bioGasCarbonDioxideTaxVAT"`
+	BiogasEnergyTax                    *float64  `json:"biogasEnergyTax" parquet:"biogasEnergyTax"`
+	BiogasEnergyTaxVAT                 *float64  `json:"biogasEnergyTaxVAT" parquet:"biogasEnergyTaxVAT"`
+	BiogasAccumulatedTax               *float64  `json:"biogasAccumulatedTax" parquet:"biogasAccumulatedTax"`
+	BiogasAccumulatedTaxVAT            *float64  `json:"biogasAccumulatedTaxVAT" parquet:"biogasAccumulatedTaxVAT"`
+}
+
+// costRowsWrapper accepts the costs of any energy class
+type costRowsWrapper struct {
+	CostsWrapper
+	Costs []struct {
+		Month             FlexibleTime    `json:"month"`
+		RetailCost        *float64        `json:"retailCost"`
+		RetailCostVAT     *float64        `json:"retailCostVAT"`
+		EnergyTax         *float64        `json:"energyTax"`
+		EnergyTaxVAT      *float64        `json:"energyTaxVAT"`
+		NetCost           *float64        `json:"netCost"`
+		NetCostVAT        *float64        `json:"netCostVAT"`
+		EffectCost        *float64        `json:"effectCost"`
+		EffectCostVAT     *float64        `json:"effectCostVAT"`
+		EnergyCost        *float64        `json:"energyCost"`
+		EnergyCostVAT     *float64        `json:"energyCostVAT"`
+		FlowCost          *float64        `json:"flowCost"`
+		FlowCostVAT       *float64        `json:"flowCostVAT"`
+		CostGridDetails   json.RawMessage `json:"costGridDetails"`
+		CostBioGasDetails json.RawMessage `json:"costBioGasDetails"`
+	} `json:"costs"`
+}
+
+// MeasurementRows flattens measurements into rows labelled with the series metadata
+func MeasurementRows(m MeasurementsWrapper, meta SeriesMetadata) []MeasurementRow {
+	seriesID := m.ID
+	if seriesID == 0 {
+		seriesID = meta.Series.ID
+	}
+
+	rows := make([]MeasurementRow, 0, len(m.Measurements))
+	for _, measurement := range m.Measurements {
+		rows = append(rows, MeasurementRow{
+			InstallationID: meta.Installation.ID,
+			SeriesID:       int64(seriesID),
+			SeriesType:     meta.Series.SeriesType,
+			Unit:           meta.Series.Unit,
+			Resolution:     m.Resolution,
+			TimeStamp:      measurement.TimeStamp.Time,
+			Value:          measurement.Value,
+		})
+	}
+	return rows
+}
+
+// CostRows flattens the result of GetCosts into rows, expanding grid and biogas details into columns.
+// Any of the typed cost wrappers, or the generic value returned by GetCosts, is accepted.
+func CostRows(costs interface{}) ([]CostRow, error) {
+	b, err := json.Marshal(costs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode costs: %w", err)
+	}
+
+	var wrapper costRowsWrapper
+	if err := json.Unmarshal(b, &wrapper); err != nil {
+		return nil, fmt.Errorf("failed to decode costs: %w", err)
+	}
+
+	rows := make([]CostRow, 0, len(wrapper.Costs))
+	for _, cost := range wrapper.Costs {
+		row := CostRow{
+			Installation:  wrapper.Installation,
+			EnergyClass:   wrapper.EnergyClass,
+			Month:         cost.Month.Time,
+			RetailCost:    cost.RetailCost,
+			RetailCostVAT: cost.RetailCostVAT,
+			EnergyTax:     cost.EnergyTax,
+			EnergyTaxVAT:  cost.EnergyTaxVAT,
+			NetCost:       cost.NetCost,
+			NetCostVAT:    cost.NetCostVAT,
+			EffectCost:    cost.EffectCost,
+			EffectCostVAT: cost.EffectCostVAT,
+			EnergyCost:    cost.EnergyCost,
+			EnergyCostVAT: cost.EnergyCostVAT,
+			FlowCost:      cost.FlowCost,
+			FlowCostVAT:   cost.FlowCostVAT,
+		}
+		// Details share their JSON names with the row columns
+		for _, details := range []json.RawMessage{cost.CostGridDetails, cost.CostBioGasDetails} {
+			if len(details) == 0 {
+				continue
+			}
+			if err := json.Unmarshal(details, &row); err != nil {
+				return nil, fmt.Errorf("failed to decode cost details: %w", err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// WriteParquet writes rows (MeasurementRow or CostRow) as a single Parquet file
+//
+// Example:
+//
+//	rows := eon.MeasurementRows(measurements, meta)
+//	err := eon.WriteParquet(file, rows)
+func WriteParquet[T MeasurementRow | CostRow](w io.Writer, rows []T) error {
+	return parquet.Write(w, rows)
+}
+
+// WriteMeasurementsParquet writes measurement rows below dir, partitioned by installation and month
+// using Hive-style directories (installation=<id>/month=<YYYY-MM>/measurements.parquet), with the
+// ID path-escaped.
+// The paths of the written files are returned.
+func WriteMeasurementsParquet(dir string, rows []MeasurementRow) ([]string, error) {
+	return writePartitions(dir, "measurements.parquet", rows, measurementPartition, WriteParquet[MeasurementRow])
+}
+
+// WriteCostsParquet writes cost rows below dir, partitioned by installation and month
+// using Hive-style directories (installation=<id>/month=<YYYY-MM>/costs.parquet), with the
+// ID path-escaped.
+// The paths of the written files are returned.
+func WriteCostsParquet(dir string, rows []CostRow) ([]string, error) {
+	return writePartitions(dir, "costs.parquet", rows, costPartition, WriteParquet[CostRow])
+}
+
+// measurementPartition returns the installation and time a measurement row is partitioned by
+func measurementPartition(row MeasurementRow) (string, time.Time) {
+	return row.InstallationID, row.TimeStamp
+}
+
+// costPartition returns the installation and month a cost row is partitioned by
+func costPartition(row CostRow) (string, time.Time) {
+	return row.Installation, row.Month
+}
+
+// writePartitions groups rows by installation and month and writes one file per group with write
+func writePartitions[T MeasurementRow | CostRow](dir, name string, rows []T, key func(T) (string, time.Time), write func(io.Writer, []T) error) ([]string, error) {
+	partitions := map[string][]T{}
+	for _, row := range rows {
+		installation, ts := key(row)
+		if installation == "" {
+			installation = "unknown"
+		}
+		// Escape separators so that installation IDs can't leave dir
+		path := filepath.Join(dir, "installation="+url.PathEscape(installation), "month="+ts.Format("2006-01"), name)
+		partitions[path] = append(partitions[path], row)
+	}
+
+	paths := make([]string, 0, len(partitions))
+	for path := range partitions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := write(f, partitions[path]); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
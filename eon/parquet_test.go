@@ -0,0 +1,160 @@
+package eon
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasurementRows(t *testing.T) {
+	value := 3.5
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := MeasurementRows(MeasurementsWrapper{
+		ID:         737605,
+		Resolution: "hour",
+		Measurements: []MeasurementDto{
+			{TimeStamp: FlexibleTime{Time: ts}, Value: &value},
+			{TimeStamp: FlexibleTime{Time: ts.Add(time.Hour)}},
+		},
+	}, SeriesMetadata{
+		Installation: InstallationDto{ID: "inst-1"},
+		Series:       MeasurementSeriesDto{SeriesType: "ElectricActive", Unit: "KWH"},
+	})
+
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "inst-1", rows[0].InstallationID)
+	assert.Equal(t, int64(737605), rows[0].SeriesID)
+	assert.Equal(t, "KWH", rows[0].Unit)
+	assert.Equal(t, 3.5, *rows[0].Value)
+	assert.Nil(t, rows[1].Value)
+}
+
+func TestCostRows(t *testing.T) {
+	t.Run("expands grid details from generic response", func(t *testing.T) {
+		costs := map[string]interface{}{
+			"energyClass":  "El",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{
+					"month":      "2024-01-01T00:00:00",
+					"retailCost": 100.0,
+					"costGridDetails": map[string]interface{}{
+						"gridEffect": 25.0,
+					},
+				},
+			},
+		}
+
+		rows, err := CostRows(costs)
+
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, "inst-1", rows[0].Installation)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), rows[0].Month)
+		assert.Equal(t, 100.0, *rows[0].RetailCost)
+		assert.Equal(t, 25.0, *rows[0].GridEffect)
+		assert.Nil(t, rows[0].NetCost)
+		assert.Nil(t, rows[0].BiogasEnergyTax)
+	})
+
+	t.Run("expands biogas details from typed wrapper", func(t *testing.T) {
+		tax := 12.0
+		costs := CostsGasWrapper{
+			CostsWrapper: CostsWrapper{EnergyClass: "Gas", Installation: "inst-2"},
+			Costs: []CostGasDto{
+				{
+					CostsBaseDto:      CostsBaseDto{Month: FlexibleTime{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}},
+					CostBioGasDetails: &CostBioGasDetailsDto{BiogasEnergyTax: &tax},
+				},
+			},
+		}
+
+		rows, err := CostRows(costs)
+
+		assert.NoError(t, err)
+		assert.Len(t, rows, 1)
+		assert.Equal(t, 12.0, *rows[0].BiogasEnergyTax)
+		assert.Nil(t, rows[0].GridEffect)
+	})
+
+	t.Run("returns error for unexpected shape", func(t *testing.T) {
+		_, err := CostRows([]string{"not", "costs"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteParquet(t *testing.T) {
+	value := 1.25
+	rows := []MeasurementRow{
+		{InstallationID: "inst-1", TimeStamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: &value},
+		{InstallationID: "inst-1", TimeStamp: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+	}
+
+	var buf bytes.Buffer
+	err := WriteParquet(&buf, rows)
+	assert.NoError(t, err)
+
+	read, err := parquet.Read[MeasurementRow](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, read, 2)
+	assert.Equal(t, 1.25, *read[0].Value)
+	assert.Nil(t, read[1].Value)
+	assert.True(t, rows[0].TimeStamp.Equal(read[0].TimeStamp))
+
+	schema := parquet.SchemaOf(MeasurementRow{})
+	assert.True(t, schema.Fields()[6].Optional())
+	assert.Equal(t, "installationId", schema.Fields()[0].Name())
+
+	costSchema := parquet.SchemaOf(CostRow{})
+	_, ok := costSchema.Lookup("gridEffectVAT")
+	assert.True(t, ok)
+}
+
+func TestWriteMeasurementsParquet(t *testing.T) {
+	dir := t.TempDir()
+	rows := []MeasurementRow{
+		{InstallationID: "inst-1", TimeStamp: time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)},
+		{InstallationID: "inst-1", TimeStamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{InstallationID: "inst-2", TimeStamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	paths, err := WriteMeasurementsParquet(dir, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "installation=inst-1", "month=2024-01", "measurements.parquet"),
+		filepath.Join(dir, "installation=inst-1", "month=2024-02", "measurements.parquet"),
+		filepath.Join(dir, "installation=inst-2", "month=2024-02", "measurements.parquet"),
+	}, paths)
+
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	}
+}
+
+func TestWriteCostsParquet(t *testing.T) {
+	dir := t.TempDir()
+	rows := []CostRow{
+		{Installation: "inst-1", Month: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	paths, err := WriteCostsParquet(dir, rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "installation=inst-1", "month=2024-03", "costs.parquet")}, paths)
+
+	t.Run("escapes installation IDs", func(t *testing.T) {
+		paths, err := WriteCostsParquet(dir, []CostRow{{Installation: "../../escaped", Month: rows[0].Month}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(dir, "installation=..%2F..%2Fescaped", "month=2024-03", "costs.parquet")}, paths)
+	})
+}
@@ -0,0 +1,195 @@
+package eon
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// PeakOptions configures AnalyzePeaks
+type PeakOptions struct {
+	Top          int            // Number of peaks per month, 3 if zero
+	DistinctDays bool           // Only count the highest value of each day, as effect tariffs commonly do
+	Location     *time.Location // Time zone for months and days, UTC if nil
+	Voltage      float64        // Line-to-line voltage of the three-phase connection, 400 V if zero
+}
+
+// Peak is the average power during a single hour
+type Peak struct {
+	Time  time.Time `json:"time"`
+	Power float64   `json:"power"` // kW
+}
+
+// MonthlyPeaks holds the highest peaks of a month.
+// FuseUtilisation is the highest peak relative to the fuse capacity (0-1), nil without a safety level.
+type MonthlyPeaks struct {
+	Month           time.Time `json:"month"`
+	Peaks           []Peak    `json:"peaks"`
+	Average         float64   `json:"average"` // Average of the peaks in kW
+	Max             Peak      `json:"max"`
+	FuseUtilisation *float64  `json:"fuseUtilisation"`
+}
+
+// PeakReport holds peak demand per month and overall
+type PeakReport struct {
+	SeriesID        int            `json:"seriesId"`
+	Resolution      string         `json:"resolution"`
+	Top             int            `json:"top"`
+	DistinctDays    bool           `json:"distinctDays"`
+	FuseCapacity    *float64       `json:"fuseCapacity"` // kW
+	Months          []MonthlyPeaks `json:"months"`
+	Max             Peak           `json:"max"`
+	FuseUtilisation *float64       `json:"fuseUtilisation"`
+}
+
+// FuseCapacity returns the maximum power in kW of a three-phase connection with the given
+// fuse size in amperes (InstallationDto.SafetyLevel) and line-to-line voltage
+func FuseCapacity(amperes, voltage float64) float64 {
+	return math.Sqrt(3) * voltage * amperes / 1000
+}
+
+// GetPeaks fetches a quarter or hour series and analyses its peak demand,
+// using the series unit and the installation's safety level from GetSeriesMetadata.
+//
+// Example:
+//
+//	report, err := eon.GetPeaks(client, 737605, eon.Hour, from, to, eon.PeakOptions{Top: 3, DistinctDays: true})
+func GetPeaks(c Client, seriesID int, resolution Resolution, from, to time.Time, opts PeakOptions) (PeakReport, error) {
+	meta, err := GetSeriesMetadata(c, seriesID)
+	if err != nil {
+		return PeakReport{}, err
+	}
+
+	measurements, err := c.GetMeasurements(seriesID, resolution, from, to, false)
+	if err != nil {
+		return PeakReport{}, err
+	}
+	if measurements.Resolution == "" {
+		measurements.Resolution = string(resolution)
+	}
+
+	return AnalyzePeaks(measurements, meta.Series.Unit, meta.Installation.SafetyLevel, opts)
+}
+
+// AnalyzePeaks returns the top hourly peaks of each month of a quarter or hour energy series, their average
+// (as used by effect tariffs) and the utilisation of the fuse given by safetyLevel (in amperes, may be nil).
+// Quarter values are summed into hours in opts.Location, and the energy of each hour in kWh, converted
+// using the series unit, is its average power in kW.
+func AnalyzePeaks(m MeasurementsWrapper, unit string, safetyLevel *float32, opts PeakOptions) (PeakReport, error) {
+	if opts.Top <= 0 {
+		opts.Top = 3
+	}
+	if opts.Voltage == 0 {
+		opts.Voltage = 400
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if _, err := intervalsPerHour(m.Resolution); err != nil {
+		return PeakReport{}, fmt.Errorf("peak analysis: %w", err)
+	}
+
+	report := PeakReport{
+		SeriesID:     m.ID,
+		Resolution:   m.Resolution,
+		Top:          opts.Top,
+		DistinctDays: opts.DistinctDays,
+		Months:       []MonthlyPeaks{},
+	}
+	if safetyLevel != nil && *safetyLevel > 0 {
+		capacity := FuseCapacity(float64(*safetyLevel), opts.Voltage)
+		report.FuseCapacity = &capacity
+	}
+	utilisation := func(p Peak) *float64 {
+		if report.FuseCapacity == nil {
+			return nil
+		}
+		u := p.Power / *report.FuseCapacity
+		return &u
+	}
+
+	// Sum the energy of each hour, as effect tariffs rank hourly averages
+	hours := map[time.Time]float64{}
+	var order []time.Time
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		energy, err := ToKWh(*measurement.Value, unit)
+		if err != nil {
+			return PeakReport{}, err
+		}
+		hour, _ := BucketHour.Start(measurement.TimeStamp.Time, loc)
+		if _, ok := hours[hour]; !ok {
+			order = append(order, hour)
+		}
+		hours[hour] += energy
+	}
+
+	// Group power values by month, keeping only the highest of each day if requested
+	months := map[time.Time][]Peak{}
+	days := map[time.Time]int{} // Index of a day's peak within its month
+	for _, hour := range order {
+		peak := Peak{Time: hour, Power: hours[hour]}
+
+		month, _ := BucketMonth.Start(peak.Time, loc)
+		if opts.DistinctDays {
+			day, _ := BucketDay.Start(peak.Time, loc)
+			if i, ok := days[day]; ok {
+				if peak.Power > months[month][i].Power {
+					months[month][i] = peak
+				}
+				continue
+			}
+			days[day] = len(months[month])
+		}
+		months[month] = append(months[month], peak)
+	}
+
+	for month, peaks := range months {
+		sort.SliceStable(peaks, func(i, j int) bool { return peaks[i].Power > peaks[j].Power })
+		if len(peaks) > opts.Top {
+			peaks = peaks[:opts.Top]
+		}
+
+		var sum float64
+		for _, p := range peaks {
+			sum += p.Power
+		}
+
+		result := MonthlyPeaks{
+			Month:   month,
+			Peaks:   peaks,
+			Average: sum / float64(len(peaks)),
+			Max:     peaks[0],
+		}
+		result.FuseUtilisation = utilisation(result.Max)
+		report.Months = append(report.Months, result)
+
+		if result.Max.Power > report.Max.Power || report.Max.Time.IsZero() {
+			report.Max = result.Max
+		}
+	}
+	sort.Slice(report.Months, func(i, j int) bool { return report.Months[i].Month.Before(report.Months[j].Month) })
+	if len(report.Months) > 0 {
+		report.FuseUtilisation = utilisation(report.Max)
+	}
+
+	return report, nil
+}
+
+// intervalsPerHour returns the number of intervals per hour of a quarter or hour resolution,
+// which converts energy per interval to average power
+func intervalsPerHour(resolution string) (float64, error) {
+	switch Resolution(resolution) {
+	case Quarter:
+		return 4, nil
+	case Hour:
+		return 1, nil
+	default:
+		return 0, fmt.Errorf("quarter or hour resolution required, got %q", resolution)
+	}
+}
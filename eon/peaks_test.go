@@ -0,0 +1,134 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuseCapacity(t *testing.T) {
+	assert.InDelta(t, 13.86, FuseCapacity(20, 400), 0.01)
+}
+
+func TestAnalyzePeaks(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	m := MeasurementsWrapper{ID: 1, Resolution: "hour"}
+	add := func(ts time.Time, v float64) {
+		m.Measurements = append(m.Measurements, MeasurementDto{TimeStamp: FlexibleTime{Time: ts}, Value: &v})
+	}
+	add(jan.Add(8*time.Hour), 5)
+	add(jan.Add(9*time.Hour), 6)
+	add(jan.Add(24*time.Hour), 4)
+	add(jan.Add(48*time.Hour), 2)
+	add(jan.Add(72*time.Hour), 1)
+	add(feb, 3)
+	m.Measurements = append(m.Measurements, MeasurementDto{TimeStamp: FlexibleTime{Time: feb.Add(time.Hour)}})
+
+	t.Run("returns top peaks per month", func(t *testing.T) {
+		report, err := AnalyzePeaks(m, "KWH", nil, PeakOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, report.Top)
+		assert.Len(t, report.Months, 2)
+		assert.Equal(t, []Peak{{jan.Add(9 * time.Hour), 6}, {jan.Add(8 * time.Hour), 5}, {jan.Add(24 * time.Hour), 4}}, report.Months[0].Peaks)
+		assert.Equal(t, 5.0, report.Months[0].Average)
+		assert.Equal(t, 3.0, report.Months[1].Average)
+		assert.Equal(t, Peak{jan.Add(9 * time.Hour), 6}, report.Max)
+		assert.Nil(t, report.FuseUtilisation)
+	})
+
+	t.Run("counts one peak per day", func(t *testing.T) {
+		report, err := AnalyzePeaks(m, "KWH", nil, PeakOptions{DistinctDays: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Peak{{jan.Add(9 * time.Hour), 6}, {jan.Add(24 * time.Hour), 4}, {jan.Add(48 * time.Hour), 2}}, report.Months[0].Peaks)
+		assert.Equal(t, 4.0, report.Months[0].Average)
+	})
+
+	t.Run("converts quarter energy to power and checks fuse", func(t *testing.T) {
+		quarter := MeasurementsWrapper{Resolution: "quarter"}
+		v := 2.0 // kWh in each quarter = 8 kW over the hour
+		for i := 0; i < 4; i++ {
+			quarter.Measurements = append(quarter.Measurements, MeasurementDto{TimeStamp: FlexibleTime{Time: jan.Add(time.Duration(i) * 15 * time.Minute)}, Value: &v})
+		}
+		fuse := float32(20)
+
+		report, err := AnalyzePeaks(quarter, "KWH", &fuse, PeakOptions{Top: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8.0, report.Max.Power)
+		assert.InDelta(t, 13.86, *report.FuseCapacity, 0.01)
+		assert.InDelta(t, 0.577, *report.FuseUtilisation, 0.001)
+		assert.InDelta(t, 0.577, *report.Months[0].FuseUtilisation, 0.001)
+	})
+
+	t.Run("ranks hourly sums of quarters", func(t *testing.T) {
+		quarter := MeasurementsWrapper{Resolution: "quarter"}
+		addQuarter := func(ts time.Time, v float64) {
+			quarter.Measurements = append(quarter.Measurements, MeasurementDto{TimeStamp: FlexibleTime{Time: ts}, Value: &v})
+		}
+		// A single high quarter at 08:00, and an even but higher total at 09:00
+		addQuarter(jan.Add(8*time.Hour), 3)
+		addQuarter(jan.Add(8*time.Hour+15*time.Minute), 0)
+		for i := 0; i < 4; i++ {
+			addQuarter(jan.Add(9*time.Hour+time.Duration(i)*15*time.Minute), 1)
+		}
+
+		report, err := AnalyzePeaks(quarter, "KWH", nil, PeakOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Peak{{jan.Add(9 * time.Hour), 4}, {jan.Add(8 * time.Hour), 3}}, report.Months[0].Peaks)
+		assert.Equal(t, Peak{jan.Add(9 * time.Hour), 4}, report.Max)
+	})
+
+	t.Run("rejects day resolution", func(t *testing.T) {
+		_, err := AnalyzePeaks(MeasurementsWrapper{Resolution: "day"}, "KWH", nil, PeakOptions{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetPeaks(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	fuse := float32(16)
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 1, Unit: "MWH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{{ID: "inst-1", SafetyLevel: &fuse}},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         1,
+			Resolution: "hour",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Value: float(0.005)},
+			},
+		}))
+
+	report, err := GetPeaks(c, 1, Hour, time.Time{}, time.Time{}, PeakOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, report.Max.Power)
+	assert.NotNil(t, report.FuseCapacity)
+}
@@ -0,0 +1,273 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PortfolioInstallation holds consumption and costs of a single installation for a period and
+// the same period one year earlier. Consumption is in kWh, costs exclude VAT unless stated.
+type PortfolioInstallation struct {
+	Installation        InstallationDto `json:"installation"`
+	SeriesID            int             `json:"seriesId"`
+	Consumption         float64         `json:"consumption"`
+	PreviousConsumption float64         `json:"previousConsumption"`
+	Cost                float64         `json:"cost"`
+	CostInclVAT         float64         `json:"costInclVAT"`
+	PreviousCost        float64         `json:"previousCost"`
+	Errors              []string        `json:"errors"`
+}
+
+// PortfolioGroup holds the totals of a group of installations.
+// Shares are fractions (0-1) of the portfolio total; deltas are year-over-year changes in percent
+// and nil when there is nothing to compare against.
+type PortfolioGroup struct {
+	Key                 string   `json:"key"`
+	Installations       int      `json:"installations"`
+	Consumption         float64  `json:"consumption"`
+	ConsumptionShare    float64  `json:"consumptionShare"`
+	PreviousConsumption float64  `json:"previousConsumption"`
+	ConsumptionDelta    *float64 `json:"consumptionDelta"`
+	Cost                float64  `json:"cost"`
+	CostInclVAT         float64  `json:"costInclVAT"`
+	CostShare           float64  `json:"costShare"`
+	PreviousCost        float64  `json:"previousCost"`
+	CostDelta           *float64 `json:"costDelta"`
+}
+
+// PortfolioReport holds consumption and costs of all installations grouped by an installation attribute
+type PortfolioReport struct {
+	GroupBy       PortfolioGroupBy        `json:"groupBy"`
+	From          time.Time               `json:"from"`
+	To            time.Time               `json:"to"`
+	Groups        []PortfolioGroup        `json:"groups"`
+	Total         PortfolioGroup          `json:"total"`
+	Installations []PortfolioInstallation `json:"installations"`
+}
+
+// key returns the attribute of an installation used for grouping
+func (g PortfolioGroupBy) key(inst InstallationDto) (string, error) {
+	var key string
+	switch g {
+	case GroupByPriceArea:
+		key = inst.PriceArea
+	case GroupByGridArea:
+		key = inst.GridArea
+	case GroupByCity:
+		key = inst.City
+	case GroupByCategory:
+		key = inst.Category
+	case GroupByBusiness:
+		key = inst.Business
+	default:
+		return "", fmt.Errorf("invalid group by: %q", g)
+	}
+	if key == "" {
+		key = "unknown"
+	}
+	return key, nil
+}
+
+// GetPortfolioReport fetches consumption and costs of every installation for the period [from, to]
+// and the same period one year earlier, and groups them by the given installation attribute.
+// Consumption is converted to kWh. Failures for a single installation are recorded in its Errors
+// instead of failing the whole report.
+//
+// Example:
+//
+//	report, err := eon.GetPortfolioReport(client, eon.GroupByPriceArea, from, to)
+func GetPortfolioReport(c Client, groupBy PortfolioGroupBy, from, to time.Time) (PortfolioReport, error) {
+	if _, err := groupBy.key(InstallationDto{}); err != nil {
+		return PortfolioReport{}, err
+	}
+
+	installations, err := c.GetInstallations(nil)
+	if err != nil {
+		return PortfolioReport{}, err
+	}
+
+	series, err := c.GetMeasurementSeries()
+	if err != nil {
+		return PortfolioReport{}, err
+	}
+
+	consumptionSeries := map[string]MeasurementSeriesDto{}
+	for _, inst := range series.Installations {
+		for _, ms := range inst.MeasurementSeries {
+			if _, ok := consumptionSeries[inst.ID]; !ok && IsConsumptionSeries(ms) {
+				consumptionSeries[inst.ID] = ms
+			}
+		}
+	}
+
+	prevFrom, prevTo := from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+
+	var results []PortfolioInstallation
+	for _, inst := range installations.Installations {
+		result := PortfolioInstallation{Installation: inst, Errors: []string{}}
+		fail := func(err error) {
+			result.Errors = append(result.Errors, err.Error())
+		}
+
+		if ms, ok := consumptionSeries[inst.ID]; ok {
+			result.SeriesID = ms.ID
+			if result.Consumption, err = consumptionKWh(c, ms, from, to); err != nil {
+				fail(err)
+			}
+			if result.PreviousConsumption, err = consumptionKWh(c, ms, prevFrom, prevTo); err != nil {
+				fail(err)
+			}
+		} else if inst.HasMeasurementsSubscription {
+			fail(fmt.Errorf("no consumption series found"))
+		}
+
+		if inst.HasCostsSubscription {
+			if total, err := totalCosts(c, inst.ID, from, to); err != nil {
+				fail(err)
+			} else {
+				result.Cost, result.CostInclVAT = total.TotalExclVAT, total.TotalInclVAT
+			}
+			if total, err := totalCosts(c, inst.ID, prevFrom, prevTo); err != nil {
+				fail(err)
+			} else {
+				result.PreviousCost = total.TotalExclVAT
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return NewPortfolioReport(results, groupBy, from, to)
+}
+
+// NewPortfolioReport groups installation results by the given installation attribute
+// and computes totals, shares and year-over-year deltas
+func NewPortfolioReport(installations []PortfolioInstallation, groupBy PortfolioGroupBy, from, to time.Time) (PortfolioReport, error) {
+	report := PortfolioReport{
+		GroupBy:       groupBy,
+		From:          from,
+		To:            to,
+		Groups:        []PortfolioGroup{},
+		Total:         PortfolioGroup{Key: "total"},
+		Installations: installations,
+	}
+	if report.Installations == nil {
+		report.Installations = []PortfolioInstallation{}
+	}
+
+	groups := map[string]*PortfolioGroup{}
+	for _, inst := range installations {
+		key, err := groupBy.key(inst.Installation)
+		if err != nil {
+			return PortfolioReport{}, err
+		}
+
+		group, ok := groups[key]
+		if !ok {
+			group = &PortfolioGroup{Key: key}
+			groups[key] = group
+		}
+
+		for _, g := range []*PortfolioGroup{group, &report.Total} {
+			g.Installations++
+			g.Consumption += inst.Consumption
+			g.PreviousConsumption += inst.PreviousConsumption
+			g.Cost += inst.Cost
+			g.CostInclVAT += inst.CostInclVAT
+			g.PreviousCost += inst.PreviousCost
+		}
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool { return report.Groups[i].Key < report.Groups[j].Key })
+
+	for _, g := range append([]*PortfolioGroup{&report.Total}, groupPointers(report.Groups)...) {
+		if report.Total.Consumption != 0 {
+			g.ConsumptionShare = g.Consumption / report.Total.Consumption
+		}
+		if report.Total.Cost != 0 {
+			g.CostShare = g.Cost / report.Total.Cost
+		}
+		g.ConsumptionDelta = percentChange(g.PreviousConsumption, g.Consumption)
+		g.CostDelta = percentChange(g.PreviousCost, g.Cost)
+	}
+
+	return report, nil
+}
+
+// groupPointers returns pointers to the elements of groups
+func groupPointers(groups []PortfolioGroup) []*PortfolioGroup {
+	pointers := make([]*PortfolioGroup, len(groups))
+	for i := range groups {
+		pointers[i] = &groups[i]
+	}
+	return pointers
+}
+
+// percentChange returns the change from previous to current in percent, or nil if previous is zero
+func percentChange(previous, current float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous * 100
+	return &change
+}
+
+// consumptionKWh sums the monthly consumption of a series over [from, to] in kWh
+func consumptionKWh(c Client, series MeasurementSeriesDto, from, to time.Time) (float64, error) {
+	measurements, err := c.GetMeasurements(series.ID, Month, from, to, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, m := range measurements.Measurements {
+		if m.Value != nil {
+			total += *m.Value
+		}
+	}
+	return ToKWh(total, series.Unit)
+}
+
+// totalCosts returns the summed costs of an installation over [from, to]
+func totalCosts(c Client, installationID string, from, to time.Time) (CostPeriodSummary, error) {
+	costs, err := c.GetCosts(installationID, &from, &to)
+	if err != nil {
+		return CostPeriodSummary{}, err
+	}
+
+	summary, err := SummarizeCosts(costs, PeriodMonth)
+	if err != nil {
+		return CostPeriodSummary{}, err
+	}
+	return summary.Total, nil
+}
+
+// ToKWh converts an energy value in Wh, kWh, MWh or GWh to kWh
+func ToKWh(value float64, unit string) (float64, error) {
+	switch strings.ToUpper(unit) {
+	case "WH":
+		return value / 1000, nil
+	case "KWH", "":
+		return value, nil
+	case "MWH":
+		return value * 1000, nil
+	case "GWH":
+		return value * 1000 * 1000, nil
+	default:
+		return 0, fmt.Errorf("unsupported energy unit: %q", unit)
+	}
+}
+
+// addTo adds v to an optional sum
+func addTo(sum *float64, v float64) *float64 {
+	if sum == nil {
+		return &v
+	}
+	total := *sum + v
+	return &total
+}
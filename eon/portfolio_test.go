@@ -0,0 +1,133 @@
+package eon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToKWh(t *testing.T) {
+	tests := []struct {
+		unit     string
+		expected float64
+	}{
+		{"WH", 0.002},
+		{"KWH", 2},
+		{"kWh", 2},
+		{"MWH", 2000},
+		{"GWh", 2000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			v, err := ToKWh(2, tt.unit)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+
+	_, err := ToKWh(2, "M3")
+	assert.Error(t, err)
+}
+
+func TestNewPortfolioReport(t *testing.T) {
+	installations := []PortfolioInstallation{
+		{Installation: InstallationDto{ID: "1", PriceArea: "SE3"}, Consumption: 300, PreviousConsumption: 200, Cost: 60},
+		{Installation: InstallationDto{ID: "2", PriceArea: "SE4"}, Consumption: 100, Cost: 40, PreviousCost: 50},
+		{Installation: InstallationDto{ID: "3", PriceArea: "SE3"}, Consumption: 0},
+	}
+
+	report, err := NewPortfolioReport(installations, GroupByPriceArea, time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Groups, 2)
+
+	se3 := report.Groups[0]
+	assert.Equal(t, "SE3", se3.Key)
+	assert.Equal(t, 2, se3.Installations)
+	assert.Equal(t, 0.75, se3.ConsumptionShare)
+	assert.Equal(t, 0.6, se3.CostShare)
+	assert.Equal(t, 50.0, *se3.ConsumptionDelta)
+	assert.Nil(t, se3.CostDelta)
+
+	se4 := report.Groups[1]
+	assert.Equal(t, -20.0, *se4.CostDelta)
+
+	assert.Equal(t, 3, report.Total.Installations)
+	assert.Equal(t, 400.0, report.Total.Consumption)
+	assert.Equal(t, 1.0, report.Total.ConsumptionShare)
+
+	_, err = NewPortfolioReport(installations, PortfolioGroupBy("street"), time.Time{}, time.Time{})
+	assert.Error(t, err)
+}
+
+func TestGetPortfolioReport(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{
+				{ID: "inst-1", City: "Malmö", HasMeasurementsSubscription: true, HasCostsSubscription: true},
+				{ID: "inst-2", City: "Lund", HasMeasurementsSubscription: true},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 1, SeriesType: "ElectricActive", Unit: "MWH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/month",
+		func(req *http.Request) (*http.Response, error) {
+			value := 2.0
+			if req.URL.Query().Get("from") == "2023-01-01T00:00:00.000Z" {
+				value = 1.0
+			}
+			return httpmock.NewJsonResponse(200, MeasurementsWrapper{
+				ID:           1,
+				Resolution:   "month",
+				Measurements: []MeasurementDto{{TimeStamp: FlexibleTime{Time: from}, Value: &value}},
+			})
+		})
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"energyClass":  "El",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{"month": "2024-01-01T00:00:00", "retailCost": 500.0, "retailCostVAT": 625.0},
+			},
+		}))
+
+	report, err := GetPortfolioReport(c, GroupByCity, from, to)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Installations, 2)
+	assert.Equal(t, 2000.0, report.Installations[0].Consumption)
+	assert.Equal(t, 1000.0, report.Installations[0].PreviousConsumption)
+	assert.Equal(t, 500.0, report.Installations[0].Cost)
+	assert.Equal(t, 625.0, report.Installations[0].CostInclVAT)
+	assert.Empty(t, report.Installations[0].Errors)
+	assert.Equal(t, []string{"no consumption series found"}, report.Installations[1].Errors)
+
+	assert.Equal(t, "Lund", report.Groups[0].Key)
+	assert.Equal(t, "Malmö", report.Groups[1].Key)
+	assert.Equal(t, 100.0, *report.Groups[1].ConsumptionDelta)
+	assert.Equal(t, 2000.0, report.Total.Consumption)
+}
@@ -0,0 +1,239 @@
+package eon
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Price is the spot price of a price area from Time until the next price, at most one hour
+type Price struct {
+	Time  time.Time `json:"time"`
+	Area  string    `json:"area"`
+	Price float64   `json:"price"` // Per kWh
+}
+
+// PriceSource provides spot prices per price area
+type PriceSource interface {
+	// Prices returns the prices of an area starting within [from, to), sorted by time
+	Prices(area string, from, to time.Time) ([]Price, error)
+}
+
+// PriceFileOptions configures LoadPriceFile
+type PriceFileOptions struct {
+	Location *time.Location // Time zone of timestamps without offset, UTC if nil
+	MWh      bool           // Prices are per MWh, as published by Nord Pool, and are converted to per kWh
+}
+
+// FilePriceSource is a PriceSource backed by prices loaded from a file
+type FilePriceSource struct {
+	prices map[string][]Price
+}
+
+// LoadPriceFile reads hourly (or quarter-hourly) spot prices from a .csv or .json file.
+//
+// CSV files have a header row and either time, area and price columns, or a time column
+// followed by one column per price area (time,SE1,SE2,SE3,SE4). JSON files hold an array
+// of objects with time, area and price fields. Timestamps are RFC 3339, or "2006-01-02 15:04"
+// in opts.Location.
+//
+// Example:
+//
+//	prices, err := eon.LoadPriceFile("nordpool-2024.csv", eon.PriceFileOptions{MWh: true})
+func LoadPriceFile(path string, opts PriceFileOptions) (*FilePriceSource, error) {
+	values, err := readAreaFile(path, "price", opts.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]Price, len(values))
+	for i, v := range values {
+		prices[i] = Price{Time: v.Time, Area: v.Area, Price: v.Value}
+		if opts.MWh {
+			prices[i].Price /= 1000
+		}
+	}
+	return NewFilePriceSource(prices), nil
+}
+
+// areaValue is a value of a price area from Time, such as a spot price or an emission factor
+type areaValue struct {
+	Time  time.Time
+	Area  string
+	Value float64
+}
+
+// readAreaFile reads a .csv or .json file of values per price area, taking the values
+// from the column or field named column
+func readAreaFile(path, column string, loc *time.Location) ([]areaValue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var values []areaValue
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		values, err = readAreaCSV(f, column, loc)
+	case ".json":
+		values, err = readAreaJSON(f, column, loc)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s (expected .csv or .json)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// NewFilePriceSource returns a PriceSource serving the given prices
+func NewFilePriceSource(prices []Price) *FilePriceSource {
+	s := &FilePriceSource{prices: map[string][]Price{}}
+	for _, p := range prices {
+		p.Area = normalizePriceArea(p.Area)
+		s.prices[p.Area] = append(s.prices[p.Area], p)
+	}
+	for _, area := range s.prices {
+		sort.SliceStable(area, func(i, j int) bool { return area[i].Time.Before(area[j].Time) })
+	}
+	return s
+}
+
+// Prices returns the prices of an area starting within [from, to).
+// ErrorNotFound is returned for areas without prices.
+func (s *FilePriceSource) Prices(area string, from, to time.Time) ([]Price, error) {
+	prices, ok := s.prices[normalizePriceArea(area)]
+	if !ok {
+		return nil, fmt.Errorf("prices for area %q: %w", area, ErrorNotFound)
+	}
+
+	start := sort.Search(len(prices), func(i int) bool { return !prices[i].Time.Before(from) })
+	end := sort.Search(len(prices), func(i int) bool { return !prices[i].Time.Before(to) })
+	return prices[start:end], nil
+}
+
+// normalizePriceArea upper-cases an area and expands bare area numbers ("3") to Swedish areas ("SE3")
+func normalizePriceArea(area string) string {
+	area = strings.ToUpper(strings.TrimSpace(area))
+	if _, err := strconv.Atoi(area); err == nil {
+		return "SE" + area
+	}
+	return area
+}
+
+// parsePriceTime parses an RFC 3339 timestamp, or a local one in loc
+func parsePriceTime(s string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04:05", time.DateTime} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q", s)
+}
+
+// readAreaCSV reads values in long (time,area,<column>) or wide (time,SE1,...) format
+func readAreaCSV(r io.Reader, column string, loc *time.Location) ([]areaValue, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	timeColumn, ok := columns["time"]
+	if !ok {
+		return nil, fmt.Errorf("missing time column")
+	}
+
+	// Long format has area and value columns, wide format one column per area
+	areas := map[int]string{}
+	areaColumn, long := columns["area"]
+	valueColumn, hasValue := columns[column]
+	if long != hasValue {
+		return nil, fmt.Errorf("area and %s columns must be used together", column)
+	}
+	if !long {
+		for i, name := range records[0] {
+			if i != timeColumn {
+				areas[i] = name
+			}
+		}
+	}
+
+	var values []areaValue
+	for line, record := range records[1:] {
+		t, err := parsePriceTime(record[timeColumn], loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line+2, err)
+		}
+
+		add := func(area, value string) error {
+			if strings.TrimSpace(value) == "" {
+				return nil // No value published
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(strings.Replace(value, ",", ".", 1)), 64)
+			if err != nil {
+				return fmt.Errorf("line %d: invalid %s: %q", line+2, column, value)
+			}
+			values = append(values, areaValue{Time: t, Area: area, Value: parsed})
+			return nil
+		}
+
+		if long {
+			if err := add(record[areaColumn], record[valueColumn]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for i, area := range areas {
+			if err := add(area, record[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, nil
+}
+
+// readAreaJSON reads an array of objects with time, area and column fields
+func readAreaJSON(r io.Reader, column string, loc *time.Location) ([]areaValue, error) {
+	var raw []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	values := make([]areaValue, len(raw))
+	for i, entry := range raw {
+		timestamp, _ := entry["time"].(string)
+		t, err := parsePriceTime(timestamp, loc)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		value, ok := entry[column].(float64)
+		if !ok {
+			return nil, fmt.Errorf("entry %d: missing %s", i, column)
+		}
+		area, _ := entry["area"].(string)
+		values[i] = areaValue{Time: t, Area: area, Value: value}
+	}
+	return values, nil
+}
@@ -0,0 +1,76 @@
+package eon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writePriceFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPriceFile(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("reads long CSV", func(t *testing.T) {
+		path := writePriceFile(t, "prices.csv", "time,area,price\n2024-01-01T01:00:00Z,SE3,0.5\n2024-01-01T00:00:00Z,se3,0.4\n2024-01-01T00:00:00Z,SE4,0.7\n")
+
+		source, err := LoadPriceFile(path, PriceFileOptions{})
+		assert.NoError(t, err)
+
+		prices, err := source.Prices(PriceAreaSE3, jan, jan.Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, []Price{{jan, "SE3", 0.4}, {jan.Add(time.Hour), "SE3", 0.5}}, prices)
+	})
+
+	t.Run("reads wide CSV per MWh in local time", func(t *testing.T) {
+		loc, _ := time.LoadLocation("Europe/Stockholm")
+		path := writePriceFile(t, "prices.csv", "Time,SE1,SE2,SE3,SE4\n2024-01-01 01:00,10,20,\"30,5\",\n")
+
+		source, err := LoadPriceFile(path, PriceFileOptions{Location: loc, MWh: true})
+		assert.NoError(t, err)
+
+		prices, err := source.Prices("3", jan, jan.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, []Price{{time.Date(2024, 1, 1, 1, 0, 0, 0, loc), "SE3", 0.0305}}, prices)
+
+		prices, err = source.Prices(PriceAreaSE4, jan, jan.Add(time.Hour))
+		assert.ErrorIs(t, err, ErrorNotFound)
+		assert.Empty(t, prices)
+	})
+
+	t.Run("reads JSON", func(t *testing.T) {
+		path := writePriceFile(t, "prices.json", `[{"time": "2024-01-01T00:00:00Z", "area": "SE1", "price": 0.2}]`)
+
+		source, err := LoadPriceFile(path, PriceFileOptions{})
+		assert.NoError(t, err)
+
+		prices, err := source.Prices(PriceAreaSE1, jan, jan.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 0.2, prices[0].Price)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"prices.xml": "",
+			"prices.csv": "area,price\nSE3,1\n",
+			"a.csv":      "time,price\n2024-01-01T00:00:00Z,1\n",
+			"b.csv":      "time,SE3\nyesterday,1\n",
+			"c.csv":      "time,SE3\n2024-01-01T00:00:00Z,cheap\n",
+			"d.csv":      "time,area,factor\n2024-01-01T00:00:00Z,SE1,30\n",
+			"e.json":     `[{"time": "2024-01-01T00:00:00Z", "area": "SE1"}]`,
+			"f.json":     `[{"time": "2024-01-01T00:00:00Z", "area": "SE1", "factor": 30}]`,
+		} {
+			_, err := LoadPriceFile(writePriceFile(t, name, content), PriceFileOptions{})
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package eon
+
+import (
+	"time"
+)
+
+// ProfileOptions configures AnalyzeProfile
+type ProfileOptions struct {
+	Location   *time.Location // Time zone for hours, weekdays and seasons, UTC if nil
+	NightStart int            // First hour of the night window
+	NightEnd   int            // Hour the night window ends (exclusive), 00-05 if both are zero
+}
+
+// HourProfile holds the average power in kW for each hour of the day (index 0-23),
+// nil for hours without data
+type HourProfile []*float64
+
+// SeasonalProfile holds the hour-of-day profile of a meteorological season
+// (winter: Dec-Feb, spring: Mar-May, summer: Jun-Aug, autumn: Sep-Nov)
+type SeasonalProfile struct {
+	Season  string      `json:"season"`
+	Hours   HourProfile `json:"hours"`
+	Average float64     `json:"average"`
+}
+
+// Profile describes the load profile of a series. Power values are averages in kW.
+//
+// Heatmap holds the hour-of-day profile of every weekday, Monday first. NightBaseload is
+// the average power during the night window and BaseloadShare the fraction (0-1) of the
+// average power it accounts for. LoadFactor is the average power divided by the peak power.
+type Profile struct {
+	SeriesID      int               `json:"seriesId"`
+	Resolution    string            `json:"resolution"`
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to"`
+	Hours         HourProfile       `json:"hours"`
+	Weekday       HourProfile       `json:"weekday"`
+	Weekend       HourProfile       `json:"weekend"`
+	Heatmap       []HourProfile     `json:"heatmap"`
+	Seasons       []SeasonalProfile `json:"seasons"`
+	Average       float64           `json:"average"`
+	Peak          float64           `json:"peak"`
+	LoadFactor    float64           `json:"loadFactor"`
+	NightBaseload float64           `json:"nightBaseload"`
+	BaseloadShare float64           `json:"baseloadShare"`
+}
+
+// seasons in order of the year, indexed by month/3 % 4
+var seasons = []string{"winter", "spring", "summer", "autumn"}
+
+// GetProfile fetches a quarter or hour series and analyses its load profile,
+// using the series unit from GetSeriesMetadata.
+//
+// Example:
+//
+//	profile, err := eon.GetProfile(client, 737605, eon.Hour, from, to, eon.ProfileOptions{Location: loc})
+func GetProfile(c Client, seriesID int, resolution Resolution, from, to time.Time, opts ProfileOptions) (Profile, error) {
+	meta, err := GetSeriesMetadata(c, seriesID)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	measurements, err := c.GetMeasurements(seriesID, resolution, from, to, false)
+	if err != nil {
+		return Profile{}, err
+	}
+	if measurements.Resolution == "" {
+		measurements.Resolution = string(resolution)
+	}
+
+	return AnalyzeProfile(measurements, meta.Series.Unit, opts)
+}
+
+// AnalyzeProfile computes the average power by hour of day for all days, weekdays, weekends,
+// each weekday and each season of a quarter or hour energy series, along with the night baseload
+// and load factor. Energy per interval is converted to average power in kW using the series unit.
+func AnalyzeProfile(m MeasurementsWrapper, unit string, opts ProfileOptions) (Profile, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	if opts.NightStart == 0 && opts.NightEnd == 0 {
+		opts.NightEnd = 5
+	}
+
+	perHour, err := intervalsPerHour(m.Resolution)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	type mean struct {
+		sum   float64
+		count int
+	}
+	var hours, weekday, weekend [24]mean
+	var heatmap [7][24]mean
+	var seasonal [4][24]mean
+	var total, night mean
+
+	profile := Profile{SeriesID: m.ID, Resolution: m.Resolution}
+
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		energy, err := ToKWh(*measurement.Value, unit)
+		if err != nil {
+			return Profile{}, err
+		}
+		power := energy * perHour
+
+		ts := measurement.TimeStamp.Time
+		if profile.From.IsZero() || ts.Before(profile.From) {
+			profile.From = ts
+		}
+		if ts.After(profile.To) {
+			profile.To = ts
+		}
+
+		local := ts.In(loc)
+		hour := local.Hour()
+		day := (int(local.Weekday()) + 6) % 7 // Monday first
+		season := int(local.Month()) / 3 % 4
+
+		targets := []*mean{&hours[hour], &heatmap[day][hour], &seasonal[season][hour], &total}
+		if day < 5 {
+			targets = append(targets, &weekday[hour])
+		} else {
+			targets = append(targets, &weekend[hour])
+		}
+		if inWindow(hour, opts.NightStart, opts.NightEnd) {
+			targets = append(targets, &night)
+		}
+		for _, t := range targets {
+			t.sum += power
+			t.count++
+		}
+
+		if power > profile.Peak {
+			profile.Peak = power
+		}
+	}
+
+	hourProfile := func(means [24]mean) HourProfile {
+		p := make(HourProfile, 24)
+		for i, m := range means {
+			if m.count > 0 {
+				avg := m.sum / float64(m.count)
+				p[i] = &avg
+			}
+		}
+		return p
+	}
+
+	profile.Hours = hourProfile(hours)
+	profile.Weekday = hourProfile(weekday)
+	profile.Weekend = hourProfile(weekend)
+	for _, day := range heatmap {
+		profile.Heatmap = append(profile.Heatmap, hourProfile(day))
+	}
+
+	profile.Seasons = []SeasonalProfile{}
+	for i, means := range seasonal {
+		var s mean
+		for _, m := range means {
+			s.sum += m.sum
+			s.count += m.count
+		}
+		if s.count == 0 {
+			continue
+		}
+		profile.Seasons = append(profile.Seasons, SeasonalProfile{
+			Season:  seasons[i],
+			Hours:   hourProfile(means),
+			Average: s.sum / float64(s.count),
+		})
+	}
+
+	if total.count > 0 {
+		profile.Average = total.sum / float64(total.count)
+	}
+	if profile.Peak > 0 {
+		profile.LoadFactor = profile.Average / profile.Peak
+	}
+	if night.count > 0 {
+		profile.NightBaseload = night.sum / float64(night.count)
+	}
+	if profile.Average > 0 {
+		profile.BaseloadShare = profile.NightBaseload / profile.Average
+	}
+
+	return profile, nil
+}
+
+// inWindow reports whether hour is within [start, end), wrapping around midnight if end < start
+func inWindow(hour, start, end int) bool {
+	if start <= end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
@@ -0,0 +1,110 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeProfile(t *testing.T) {
+	// Monday 2024-01-01 and Saturday 2024-01-06: 1 kW at night, 3 kW during the day
+	var values []*float64
+	for day := 0; day < 6; day++ {
+		for hour := 0; hour < 24; hour++ {
+			switch {
+			case day != 0 && day != 5:
+				values = append(values, nil)
+			case hour < 5:
+				values = append(values, float(1))
+			case day == 5:
+				values = append(values, float(2))
+			default:
+				values = append(values, float(3))
+			}
+		}
+	}
+	m := hourlySeries(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), values...)
+
+	t.Run("computes hour-of-day profiles", func(t *testing.T) {
+		profile, err := AnalyzeProfile(m, "KWH", ProfileOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, *profile.Hours[0])
+		assert.Equal(t, 2.5, *profile.Hours[12])
+		assert.Equal(t, 3.0, *profile.Weekday[12])
+		assert.Equal(t, 2.0, *profile.Weekend[12])
+		assert.Len(t, profile.Heatmap, 7)
+		assert.Equal(t, 3.0, *profile.Heatmap[0][12])
+		assert.Nil(t, profile.Heatmap[1][12])
+		assert.Equal(t, 2.0, *profile.Heatmap[5][12])
+		assert.Len(t, profile.Seasons, 1)
+		assert.Equal(t, "winter", profile.Seasons[0].Season)
+	})
+
+	t.Run("computes baseload and load factor", func(t *testing.T) {
+		profile, err := AnalyzeProfile(m, "KWH", ProfileOptions{})
+
+		assert.NoError(t, err)
+		average := (5*1 + 19*3 + 5*1 + 19*2) / 48.0
+		assert.InDelta(t, average, profile.Average, 1e-9)
+		assert.Equal(t, 3.0, profile.Peak)
+		assert.InDelta(t, average/3, profile.LoadFactor, 1e-9)
+		assert.Equal(t, 1.0, profile.NightBaseload)
+		assert.InDelta(t, 1/average, profile.BaseloadShare, 1e-9)
+	})
+
+	t.Run("wraps night window around midnight", func(t *testing.T) {
+		profile, err := AnalyzeProfile(m, "KWH", ProfileOptions{NightStart: 23, NightEnd: 2})
+
+		assert.NoError(t, err)
+		assert.InDelta(t, (4*1+3+2)/6.0, profile.NightBaseload, 1e-9)
+	})
+
+	t.Run("rejects day resolution", func(t *testing.T) {
+		_, err := AnalyzeProfile(MeasurementsWrapper{Resolution: "day"}, "KWH", ProfileOptions{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetProfile(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 1, Unit: "WH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{{ID: "inst-1"}},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/quarter",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         1,
+			Resolution: "quarter",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)}, Value: float(500)},
+			},
+		}))
+
+	profile, err := GetProfile(c, 1, Quarter, time.Time{}, time.Time{}, ProfileOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, *profile.Hours[12])
+	assert.Equal(t, "summer", profile.Seasons[0].Season)
+}
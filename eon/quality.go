@@ -0,0 +1,299 @@
+package eon
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// QualityOptions configures AnalyzeQuality.
+// Thresholds set to a negative value are not checked.
+type QualityOptions struct {
+	Location         *time.Location // Time zone for DST checks, UTC if nil
+	Now              time.Time      // Reference time for staleness, time.Now() if zero
+	OutlierThreshold float64        // Modified z-score above which a value is an outlier, 3.5 if zero
+
+	MaxMissingRatio float64       // Maximum share of missing intervals (0-1)
+	MaxDuplicates   int           // Maximum number of duplicate timestamps
+	MaxNegatives    int           // Maximum number of negative values
+	MaxOutliers     int           // Maximum number of outliers
+	MaxDSTAnomalies int           // Maximum number of DST anomalies
+	MaxAge          time.Duration // Maximum age of the series' last update, unchecked if zero
+}
+
+// DefaultQualityOptions returns options suitable for hourly consumption series
+func DefaultQualityOptions() QualityOptions {
+	return QualityOptions{
+		OutlierThreshold: 3.5,
+		MaxMissingRatio:  0.01,
+		MaxDuplicates:    0,
+		MaxNegatives:     0,
+		MaxOutliers:      -1,
+		MaxDSTAnomalies:  0,
+		MaxAge:           72 * time.Hour,
+	}
+}
+
+// Interval is a half-open time range [From, To)
+type Interval struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// DSTAnomaly is a local day around a DST change with an unexpected number of intervals
+type DSTAnomaly struct {
+	Day      time.Time `json:"day"`
+	Expected int       `json:"expected"`
+	Actual   int       `json:"actual"`
+}
+
+// QualityReport describes the completeness and plausibility of a measurement series
+type QualityReport struct {
+	SeriesID         int              `json:"seriesId"`
+	Resolution       string           `json:"resolution"`
+	From             time.Time        `json:"from"`
+	To               time.Time        `json:"to"`
+	Expected         int              `json:"expected"`
+	Present          int              `json:"present"`
+	Missing          int              `json:"missing"`
+	Completeness     float64          `json:"completeness"`
+	MissingIntervals []Interval       `json:"missingIntervals"`
+	Duplicates       []time.Time      `json:"duplicates"`
+	Negatives        []MeasurementDto `json:"negatives"`
+	Outliers         []MeasurementDto `json:"outliers"`
+	DSTAnomalies     []DSTAnomaly     `json:"dstAnomalies"`
+	LastUpdate       time.Time        `json:"lastUpdate"`
+	Age              time.Duration    `json:"age"`
+	Violations       []string         `json:"violations"`
+}
+
+// OK reports whether no thresholds were violated
+func (r QualityReport) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// AnalyzeQuality reports missing intervals, duplicate timestamps, negative and outlier values,
+// DST anomalies and staleness of a measurement series, and which thresholds were violated.
+// The expected interval is derived from the series resolution (quarter, hour, day or month).
+// lastUpdate is the series' MeasurementSeriesDto.LastUpdate; staleness is not checked if zero.
+//
+// Example:
+//
+//	report, err := eon.AnalyzeQuality(measurements, meta.Series.LastUpdate.Time, eon.DefaultQualityOptions())
+//	if !report.OK() {
+//	    fmt.Println(report.Violations)
+//	}
+func AnalyzeQuality(m MeasurementsWrapper, lastUpdate time.Time, opts QualityOptions) (QualityReport, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.OutlierThreshold == 0 {
+		opts.OutlierThreshold = 3.5
+	}
+
+	resolution := Resolution(m.Resolution)
+	if _, err := resolution.next(time.Time{}); err != nil {
+		return QualityReport{}, err
+	}
+
+	report := QualityReport{
+		SeriesID:         m.ID,
+		Resolution:       m.Resolution,
+		LastUpdate:       lastUpdate,
+		MissingIntervals: []Interval{},
+		Duplicates:       []time.Time{},
+		Negatives:        []MeasurementDto{},
+		Outliers:         []MeasurementDto{},
+		DSTAnomalies:     []DSTAnomaly{},
+		Violations:       []string{},
+	}
+
+	// Index values by timestamp, recording duplicates and negatives
+	present := map[int64]bool{}
+	seen := map[int64]bool{}
+	var timestamps []time.Time
+	var values []float64
+	for _, measurement := range m.Measurements {
+		ts := measurement.TimeStamp.Time
+		key := ts.UnixNano()
+		if seen[key] {
+			report.Duplicates = append(report.Duplicates, ts)
+			continue
+		}
+		seen[key] = true
+		timestamps = append(timestamps, ts)
+
+		if measurement.Value == nil {
+			continue
+		}
+		present[key] = true
+		values = append(values, *measurement.Value)
+		if *measurement.Value < 0 {
+			report.Negatives = append(report.Negatives, measurement)
+		}
+	}
+
+	if len(timestamps) > 0 {
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+		report.From = timestamps[0]
+		report.To, _ = resolution.next(timestamps[len(timestamps)-1])
+
+		// Walk every expected interval and merge consecutive missing ones
+		var gap *Interval
+		for ts := report.From; ts.Before(report.To); ts, _ = resolution.next(ts) {
+			report.Expected++
+			next, _ := resolution.next(ts)
+			if present[ts.UnixNano()] {
+				report.Present++
+				gap = nil
+				continue
+			}
+			report.Missing++
+			if gap == nil {
+				report.MissingIntervals = append(report.MissingIntervals, Interval{From: ts, To: next})
+				gap = &report.MissingIntervals[len(report.MissingIntervals)-1]
+			} else {
+				gap.To = next
+			}
+		}
+
+		report.Completeness = float64(report.Present) / float64(report.Expected)
+		report.DSTAnomalies = dstAnomalies(timestamps, resolution, loc)
+	}
+
+	report.Outliers = outliers(m.Measurements, values, opts.OutlierThreshold)
+
+	if !lastUpdate.IsZero() {
+		report.Age = opts.Now.Sub(lastUpdate)
+	}
+
+	// Evaluate thresholds
+	violate := func(format string, args ...interface{}) {
+		report.Violations = append(report.Violations, fmt.Sprintf(format, args...))
+	}
+	if opts.MaxMissingRatio >= 0 && report.Expected > 0 && 1-report.Completeness > opts.MaxMissingRatio {
+		violate("missing ratio %.4f exceeds %.4f", 1-report.Completeness, opts.MaxMissingRatio)
+	}
+	if opts.MaxDuplicates >= 0 && len(report.Duplicates) > opts.MaxDuplicates {
+		violate("%d duplicate timestamps exceed %d", len(report.Duplicates), opts.MaxDuplicates)
+	}
+	if opts.MaxNegatives >= 0 && len(report.Negatives) > opts.MaxNegatives {
+		violate("%d negative values exceed %d", len(report.Negatives), opts.MaxNegatives)
+	}
+	if opts.MaxOutliers >= 0 && len(report.Outliers) > opts.MaxOutliers {
+		violate("%d outliers exceed %d", len(report.Outliers), opts.MaxOutliers)
+	}
+	if opts.MaxDSTAnomalies >= 0 && len(report.DSTAnomalies) > opts.MaxDSTAnomalies {
+		violate("%d DST anomalies exceed %d", len(report.DSTAnomalies), opts.MaxDSTAnomalies)
+	}
+	if opts.MaxAge > 0 && !lastUpdate.IsZero() && report.Age > opts.MaxAge {
+		violate("last update %s is older than %s", lastUpdate.Format(time.RFC3339), opts.MaxAge)
+	}
+
+	return report, nil
+}
+
+// next returns the start of the interval following t for the resolution.
+// Days and months are stepped in the time zone of t, which for the API is UTC, so that the walk
+// stays on the timestamps of the series across DST changes.
+func (r Resolution) next(t time.Time) (time.Time, error) {
+	switch r {
+	case Quarter:
+		return t.Add(15 * time.Minute), nil
+	case Hour:
+		return t.Add(time.Hour), nil
+	case Day:
+		return t.AddDate(0, 0, 1), nil
+	case Month:
+		return t.AddDate(0, 1, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported resolution: %q", r)
+	}
+}
+
+// dstAnomalies reports DST change days whose number of sub-daily intervals doesn't match the day's length
+func dstAnomalies(timestamps []time.Time, resolution Resolution, loc *time.Location) []DSTAnomaly {
+	var step time.Duration
+	switch resolution {
+	case Quarter:
+		step = 15 * time.Minute
+	case Hour:
+		step = time.Hour
+	default:
+		return []DSTAnomaly{}
+	}
+
+	counts := map[time.Time]int{}
+	var days []time.Time
+	for _, ts := range timestamps {
+		day, _ := BucketDay.Start(ts, loc)
+		if _, ok := counts[day]; !ok {
+			days = append(days, day)
+		}
+		counts[day]++
+	}
+
+	anomalies := []DSTAnomaly{}
+	for _, day := range days {
+		length := day.AddDate(0, 0, 1).Sub(day)
+		if length == 24*time.Hour {
+			continue
+		}
+		// Only judge complete days, partial first/last days are reported as missing intervals
+		if day.Before(timestamps[0]) || timestamps[len(timestamps)-1].Before(day.Add(length-step)) {
+			continue
+		}
+		if expected := int(length / step); counts[day] != expected {
+			anomalies = append(anomalies, DSTAnomaly{Day: day, Expected: expected, Actual: counts[day]})
+		}
+	}
+	return anomalies
+}
+
+// outliers returns measurements whose modified z-score (based on the median absolute deviation) exceeds threshold
+func outliers(measurements []MeasurementDto, values []float64, threshold float64) []MeasurementDto {
+	result := []MeasurementDto{}
+	if len(values) < 3 {
+		return result
+	}
+
+	median := medianOf(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+	mad := medianOf(deviations)
+	if mad == 0 {
+		return result
+	}
+
+	for _, measurement := range measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		if 0.6745*math.Abs(*measurement.Value-median)/mad > threshold {
+			result = append(result, measurement)
+		}
+	}
+	return result
+}
+
+// medianOf returns the median of values without modifying the slice
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,146 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeQuality(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("reports complete series as OK", func(t *testing.T) {
+		m := hourlySeries(from, float(1), float(2), float(3), float(2))
+		opts := DefaultQualityOptions()
+		opts.Now = now
+
+		report, err := AnalyzeQuality(m, now.Add(-time.Hour), opts)
+
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.Equal(t, 4, report.Expected)
+		assert.Equal(t, 4, report.Present)
+		assert.Equal(t, 1.0, report.Completeness)
+		assert.Empty(t, report.MissingIntervals)
+	})
+
+	t.Run("reports complete daily series across DST as OK", func(t *testing.T) {
+		stockholm, err := time.LoadLocation("Europe/Stockholm")
+		assert.NoError(t, err)
+		m := MeasurementsWrapper{ID: 12345, Resolution: "day"}
+		for i := 0; i < 5; i++ {
+			m.Measurements = append(m.Measurements, MeasurementDto{
+				TimeStamp: FlexibleTime{Time: time.Date(2024, 3, 29+i, 0, 0, 0, 0, time.UTC)},
+				Value:     float(1),
+			})
+		}
+		opts := DefaultQualityOptions()
+		opts.Location = stockholm
+		opts.Now = time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)
+
+		report, err := AnalyzeQuality(m, opts.Now.Add(-time.Hour), opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, report.Expected)
+		assert.Equal(t, 5, report.Present)
+		assert.Empty(t, report.MissingIntervals)
+		assert.True(t, report.OK())
+	})
+
+	t.Run("merges nil values and absent timestamps into missing intervals", func(t *testing.T) {
+		m := hourlySeries(from, float(1), nil, float(3), float(4))
+		m.Measurements = append(m.Measurements, MeasurementDto{
+			TimeStamp: FlexibleTime{Time: from.Add(6 * time.Hour)},
+			Value:     float(5),
+		})
+		m.Measurements = append(m.Measurements[:2], m.Measurements[3:]...) // Drop 02:00
+
+		opts := DefaultQualityOptions()
+		opts.Now = now
+
+		report, err := AnalyzeQuality(m, time.Time{}, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, report.Expected)
+		assert.Equal(t, 3, report.Present)
+		assert.Equal(t, []Interval{
+			{From: from.Add(time.Hour), To: from.Add(3 * time.Hour)},
+			{From: from.Add(4 * time.Hour), To: from.Add(6 * time.Hour)},
+		}, report.MissingIntervals)
+		assert.False(t, report.OK())
+	})
+
+	t.Run("reports duplicates, negatives and outliers", func(t *testing.T) {
+		m := hourlySeries(from, float(1), float(1.1), float(0.9), float(-1), float(1), float(50))
+		m.Measurements = append(m.Measurements, m.Measurements[0])
+
+		opts := DefaultQualityOptions()
+		opts.Now = now
+		opts.MaxOutliers = 0
+
+		report, err := AnalyzeQuality(m, time.Time{}, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{from}, report.Duplicates)
+		assert.Len(t, report.Negatives, 1)
+		assert.Len(t, report.Outliers, 2)
+		assert.Len(t, report.Violations, 3)
+	})
+
+	t.Run("reports stale series", func(t *testing.T) {
+		m := hourlySeries(from, float(1))
+		opts := DefaultQualityOptions()
+		opts.Now = now
+
+		report, err := AnalyzeQuality(m, now.Add(-96*time.Hour), opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 96*time.Hour, report.Age)
+		assert.Contains(t, report.Violations[0], "older than")
+	})
+
+	t.Run("negative thresholds disable checks", func(t *testing.T) {
+		m := hourlySeries(from, float(-1), nil)
+		opts := QualityOptions{MaxMissingRatio: -1, MaxDuplicates: -1, MaxNegatives: -1, MaxOutliers: -1, MaxDSTAnomalies: -1}
+
+		report, err := AnalyzeQuality(m, time.Time{}, opts)
+
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+	})
+
+	t.Run("reports DST anomalies", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Stockholm")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+
+		// 2024-10-27 has 25 local hours, a series delivering only 24 of them is suspicious
+		day := time.Date(2024, 10, 27, 0, 0, 0, 0, loc)
+		var values []*float64
+		for i := 0; i < 27; i++ {
+			values = append(values, float(1))
+		}
+		m := hourlySeries(day.Add(-time.Hour), values...)
+		m.Measurements = append(m.Measurements[:4], m.Measurements[5:]...)
+
+		opts := DefaultQualityOptions()
+		opts.Location = loc
+		opts.MaxMissingRatio = -1
+
+		report, err := AnalyzeQuality(m, time.Time{}, opts)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []DSTAnomaly{{Day: day, Expected: 25, Actual: 24}}, report.DSTAnomalies)
+		assert.Len(t, report.MissingIntervals, 1)
+		assert.False(t, report.OK())
+	})
+
+	t.Run("rejects unknown resolution", func(t *testing.T) {
+		_, err := AnalyzeQuality(MeasurementsWrapper{Resolution: "week"}, time.Time{}, DefaultQualityOptions())
+
+		assert.Error(t, err)
+	})
+}
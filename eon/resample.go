@@ -0,0 +1,184 @@
+package eon
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Resample aggregates measurements into buckets of the given size.
+// Buckets are aligned to calendar boundaries in loc (UTC if nil), so days, weeks,
+// months and years follow local midnight across DST changes.
+// Custom bucket sizes of up to a day are aligned to local midnight, longer ones to the Unix epoch
+// in UTC, so they don't follow local midnight.
+//
+// Nil values are ignored. A bucket that only contains nil values gets a nil value,
+// except for AggregateCount which counts the non-nil values.
+//
+// Example:
+//
+//	loc, _ := time.LoadLocation("Europe/Stockholm")
+//	weekly, err := eon.Resample(measurements, eon.BucketISOWeek, eon.AggregateSum, loc)
+func Resample(m MeasurementsWrapper, bucket Bucket, agg Aggregation, loc *time.Location) (MeasurementsWrapper, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	start, err := bucket.starter(loc)
+	if err != nil {
+		return MeasurementsWrapper{}, err
+	}
+
+	aggregate, err := agg.aggregator()
+	if err != nil {
+		return MeasurementsWrapper{}, err
+	}
+
+	var keys []time.Time
+	buckets := map[time.Time][]float64{}
+	for _, measurement := range m.Measurements {
+		key := start(measurement.TimeStamp.Time)
+		values, seen := buckets[key]
+		if !seen {
+			keys = append(keys, key)
+		}
+		if measurement.Value != nil {
+			values = append(values, *measurement.Value)
+		}
+		buckets[key] = values
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Before(keys[j]) })
+
+	result := MeasurementsWrapper{
+		ID:           m.ID,
+		Resolution:   string(bucket),
+		Measurements: make([]MeasurementDto, 0, len(keys)),
+	}
+	for _, key := range keys {
+		result.Measurements = append(result.Measurements, MeasurementDto{
+			TimeStamp: FlexibleTime{Time: key},
+			Value:     aggregate(buckets[key]),
+		})
+	}
+
+	return result, nil
+}
+
+// Start returns the start of the bucket containing t, in loc (UTC if nil)
+func (b Bucket) Start(t time.Time, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	start, err := b.starter(loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start(t), nil
+}
+
+// starter returns a function mapping a time to the start of its bucket
+func (b Bucket) starter(loc *time.Location) (func(time.Time) time.Time, error) {
+	switch b {
+	case BucketHour:
+		return subDayStarter(time.Hour, loc), nil
+	case BucketDay:
+		return func(t time.Time) time.Time {
+			t = t.In(loc)
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}, nil
+	case BucketWeek:
+		return func(t time.Time) time.Time {
+			t = t.In(loc)
+			return time.Date(t.Year(), t.Month(), t.Day()-int(t.Weekday()), 0, 0, 0, 0, loc)
+		}, nil
+	case BucketISOWeek:
+		return func(t time.Time) time.Time {
+			t = t.In(loc)
+			offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+			return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+		}, nil
+	case BucketMonth:
+		return func(t time.Time) time.Time {
+			t = t.In(loc)
+			return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}, nil
+	case BucketYear:
+		return func(t time.Time) time.Time {
+			t = t.In(loc)
+			return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc)
+		}, nil
+	}
+
+	size, err := time.ParseDuration(string(b))
+	if err != nil || size <= 0 {
+		return nil, fmt.Errorf("invalid bucket: %q", b)
+	}
+
+	if size > 24*time.Hour {
+		epoch := time.Unix(0, 0)
+		return func(t time.Time) time.Time {
+			offset := t.Sub(epoch) % size
+			if offset < 0 {
+				offset += size
+			}
+			return t.Add(-offset).In(loc)
+		}, nil
+	}
+	return subDayStarter(size, loc), nil
+}
+
+// subDayStarter returns a function mapping a time to the start of its bucket of size, counting the
+// elapsed time since local midnight. Both hours repeated when DST ends get their own bucket.
+func subDayStarter(size time.Duration, loc *time.Location) func(time.Time) time.Time {
+	return func(t time.Time) time.Time {
+		t = t.In(loc)
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		elapsed := t.Sub(midnight)
+		return midnight.Add(elapsed - elapsed%size)
+	}
+}
+
+// aggregator returns a function reducing the values of a bucket
+func (a Aggregation) aggregator() (func([]float64) *float64, error) {
+	switch a {
+	case AggregateCount:
+		return func(values []float64) *float64 {
+			count := float64(len(values))
+			return &count
+		}, nil
+	case AggregateSum, AggregateMean, AggregateMin, AggregateMax:
+	default:
+		return nil, fmt.Errorf("invalid aggregation: %q", a)
+	}
+
+	return func(values []float64) *float64 {
+		if len(values) == 0 {
+			return nil
+		}
+
+		var result float64
+		switch a {
+		case AggregateSum, AggregateMean:
+			for _, v := range values {
+				result += v
+			}
+			if a == AggregateMean {
+				result /= float64(len(values))
+			}
+		case AggregateMin:
+			result = math.Inf(1)
+			for _, v := range values {
+				result = math.Min(result, v)
+			}
+		case AggregateMax:
+			result = math.Inf(-1)
+			for _, v := range values {
+				result = math.Max(result, v)
+			}
+		}
+		return &result
+	}, nil
+}
@@ -0,0 +1,143 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hourlySeries(from time.Time, values ...*float64) MeasurementsWrapper {
+	m := MeasurementsWrapper{ID: 12345, Resolution: "hour"}
+	for i, v := range values {
+		m.Measurements = append(m.Measurements, MeasurementDto{
+			TimeStamp: FlexibleTime{Time: from.Add(time.Duration(i) * time.Hour)},
+			Value:     v,
+		})
+	}
+	return m
+}
+
+func float(v float64) *float64 { return &v }
+
+func TestResample(t *testing.T) {
+	from := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC)
+	m := hourlySeries(from, float(1), float(2), nil, float(4), nil)
+
+	t.Run("aggregates per day", func(t *testing.T) {
+		tests := []struct {
+			agg      Aggregation
+			expected []float64
+		}{
+			{AggregateSum, []float64{3, 4}},
+			{AggregateMean, []float64{1.5, 4}},
+			{AggregateMin, []float64{1, 4}},
+			{AggregateMax, []float64{2, 4}},
+			{AggregateCount, []float64{2, 1}},
+		}
+
+		for _, tt := range tests {
+			t.Run(string(tt.agg), func(t *testing.T) {
+				result, err := Resample(m, BucketDay, tt.agg, nil)
+
+				assert.NoError(t, err)
+				assert.Equal(t, "day", result.Resolution)
+				assert.Len(t, result.Measurements, 2)
+				assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), result.Measurements[0].TimeStamp.Time)
+				assert.Equal(t, tt.expected[0], *result.Measurements[0].Value)
+				assert.Equal(t, tt.expected[1], *result.Measurements[1].Value)
+			})
+		}
+	})
+
+	t.Run("bucket with only nil values is nil", func(t *testing.T) {
+		result, err := Resample(hourlySeries(from, nil, nil), BucketDay, AggregateSum, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Measurements, 1)
+		assert.Nil(t, result.Measurements[0].Value)
+	})
+
+	t.Run("aggregates in configured time zone", func(t *testing.T) {
+		loc := time.FixedZone("CET", 3600)
+		result, err := Resample(m, BucketDay, AggregateSum, loc)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Measurements, 2)
+		assert.True(t, time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Equal(result.Measurements[0].TimeStamp.Time))
+		assert.Equal(t, 1.0, *result.Measurements[0].Value)
+		assert.Equal(t, 6.0, *result.Measurements[1].Value)
+	})
+
+	t.Run("rejects invalid bucket and aggregation", func(t *testing.T) {
+		_, err := Resample(m, Bucket("fortnight"), AggregateSum, nil)
+		assert.Error(t, err)
+
+		_, err = Resample(m, BucketDay, Aggregation("median"), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestBucketStart(t *testing.T) {
+	ts := time.Date(2024, 3, 6, 13, 45, 0, 0, time.UTC) // Wednesday
+
+	tests := []struct {
+		bucket   Bucket
+		expected time.Time
+	}{
+		{BucketHour, time.Date(2024, 3, 6, 13, 0, 0, 0, time.UTC)},
+		{BucketDay, time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{BucketWeek, time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{BucketISOWeek, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{BucketMonth, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{BucketYear, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Bucket("6h"), time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)},
+		{Bucket("15m"), time.Date(2024, 3, 6, 13, 45, 0, 0, time.UTC)},
+		{Bucket("168h"), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)}, // Thursday, like the Unix epoch
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.bucket), func(t *testing.T) {
+			start, err := tt.bucket.Start(ts, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, start)
+		})
+	}
+
+	t.Run("ISO week of a Sunday starts on the previous Monday", func(t *testing.T) {
+		start, err := BucketISOWeek.Start(time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), start)
+	})
+
+	t.Run("day bucket spans DST change", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Stockholm")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+
+		start, err := BucketDay.Start(time.Date(2024, 3, 31, 22, 30, 0, 0, time.UTC), loc)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, loc), start)
+	})
+
+	t.Run("hour buckets keep both hours repeated when DST ends", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Stockholm")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+
+		m := hourlySeries(time.Date(2024, 10, 26, 23, 0, 0, 0, time.UTC), float(1), float(2), float(3), float(4))
+		result, err := Resample(m, BucketHour, AggregateSum, loc)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Measurements, 4)
+		for i, measurement := range result.Measurements {
+			assert.Equal(t, m.Measurements[i].TimeStamp.Unix(), measurement.TimeStamp.Unix())
+			assert.Equal(t, *m.Measurements[i].Value, *measurement.Value)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package eon
+
+import (
+	"fmt"
+)
+
+// SeriesMetadata describes a measurement series together with the installation it belongs to
+type SeriesMetadata struct {
+	Installation InstallationDto
+	Series       MeasurementSeriesDto
+}
+
+// GetSeriesMetadata looks up a measurement series and the installation it belongs to.
+// ErrorNotFound is returned if no installation exposes the series.
+//
+// Example:
+//
+//	meta, err := eon.GetSeriesMetadata(client, 737605)
+func GetSeriesMetadata(c Client, seriesID int) (SeriesMetadata, error) {
+	series, err := c.GetMeasurementSeries()
+	if err != nil {
+		return SeriesMetadata{}, err
+	}
+
+	for _, inst := range series.Installations {
+		for _, ms := range inst.MeasurementSeries {
+			if ms.ID != seriesID {
+				continue
+			}
+
+			meta := SeriesMetadata{
+				Installation: InstallationDto{ID: inst.ID},
+				Series:       ms,
+			}
+
+			installations, err := c.GetInstallations([]string{inst.ID})
+			if err != nil {
+				return SeriesMetadata{}, err
+			}
+			for _, i := range installations.Installations {
+				if i.ID == inst.ID {
+					meta.Installation = i
+					break
+				}
+			}
+
+			return meta, nil
+		}
+	}
+
+	return SeriesMetadata{}, fmt.Errorf("measurement series %d: %w", seriesID, ErrorNotFound)
+}
+
+// Tags returns the series metadata as key/value pairs suitable for labelling exported data.
+// Empty values are omitted.
+func (m SeriesMetadata) Tags() map[string]string {
+	tags := map[string]string{}
+
+	add := func(key, value string) {
+		if value != "" {
+			tags[key] = value
+		}
+	}
+
+	add("installation_id", m.Installation.ID)
+	add("grid_area", m.Installation.GridArea)
+	add("price_area", m.Installation.PriceArea)
+	add("series_type", m.Series.SeriesType)
+	add("unit", m.Series.Unit)
+	if m.Series.ID != 0 {
+		tags["series_id"] = fmt.Sprint(m.Series.ID)
+	}
+
+	return tags
+}
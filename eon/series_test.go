@@ -0,0 +1,64 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSeriesMetadata(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{
+					ID: "inst-1",
+					MeasurementSeries: []MeasurementSeriesDto{
+						{ID: 737605, SeriesType: "ElectricActive", Unit: "KWH"},
+					},
+				},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{
+				{ID: "inst-1", GridArea: "SKN", PriceArea: "SE4"},
+			},
+		}))
+
+	t.Run("finds series and installation", func(t *testing.T) {
+		meta, err := GetSeriesMetadata(c, 737605)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "inst-1", meta.Installation.ID)
+		assert.Equal(t, "SE4", meta.Installation.PriceArea)
+		assert.Equal(t, map[string]string{
+			"installation_id": "inst-1",
+			"grid_area":       "SKN",
+			"price_area":      "SE4",
+			"series_type":     "ElectricActive",
+			"unit":            "KWH",
+			"series_id":       "737605",
+		}, meta.Tags())
+	})
+
+	t.Run("returns ErrorNotFound for unknown series", func(t *testing.T) {
+		_, err := GetSeriesMetadata(c, 1)
+
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+}
@@ -0,0 +1,385 @@
+package eon
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerOptions configures NewServer
+type ServerOptions struct {
+	APIKeys     []string      // Keys accepted in the X-API-Key header or as bearer tokens; no authentication if empty
+	CORSOrigins []string      // Origins allowed to call the API, "*" for any; no CORS headers if empty
+	CacheTTL    time.Duration // How long successful responses are cached; no caching if zero
+	Cache       Cache         // Cache of responses; an in-memory LRU cache if nil
+}
+
+// CostRowsWrapper holds the costs of an installation as typed rows, whatever its energy class
+type CostRowsWrapper struct {
+	Installation string    `json:"installation"`
+	EnergyClass  string    `json:"energyClass"`
+	Costs        []CostRow `json:"costs"`
+}
+
+// ServerError is the body of error responses of the server
+type ServerError struct {
+	Error string `json:"error"`
+}
+
+// Server is an HTTP gateway exposing the client as a REST API, so callers don't need to handle OAuth.
+// It serves the routes described by OpenAPI.
+type Server struct {
+	client Client
+	opts   ServerOptions
+	mux    *http.ServeMux
+}
+
+// badRequest marks invalid request parameters
+type badRequest struct {
+	err error
+}
+
+func (e badRequest) Error() string { return e.err.Error() }
+
+// serverParam describes a path or query parameter of a route
+type serverParam struct {
+	name        string
+	in          string // path or query
+	schema      reflect.Type
+	required    bool
+	description string
+}
+
+// serverRoute describes a route of the server, used both to serve it and to document it
+type serverRoute struct {
+	method   string
+	path     string
+	summary  string
+	params   []serverParam
+	response reflect.Type
+	handle   func(c Client, r *http.Request) (interface{}, error)
+}
+
+var (
+	stringType = reflect.TypeOf("")
+	intType    = reflect.TypeOf(0)
+	boolType   = reflect.TypeOf(false)
+	timeType   = reflect.TypeOf(time.Time{})
+	rangeDoc   = "RFC 3339 timestamp or YYYY-MM-DD (UTC)"
+)
+
+// serverRoutes lists every route of the server
+var serverRoutes = []serverRoute{
+	{
+		method:   http.MethodGet,
+		path:     "/installations",
+		summary:  "List installations",
+		params:   []serverParam{{name: "id", in: "query", schema: reflect.TypeOf([]string{}), description: "Installation IDs to include, all if none"}},
+		response: reflect.TypeOf(InstallationsWrapper{}),
+		handle: func(c Client, r *http.Request) (interface{}, error) {
+			return c.GetInstallations(r.URL.Query()["id"])
+		},
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/installations/measurement-series",
+		summary:  "List measurement series per installation",
+		response: reflect.TypeOf(InstallationsMeasurementsWrapper{}),
+		handle: func(c Client, r *http.Request) (interface{}, error) {
+			return c.GetMeasurementSeries()
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/measurements/{id}",
+		summary: "Get measurements of a series; ranges longer than the API allows are fetched in chunks",
+		params: []serverParam{
+			{name: "id", in: "path", schema: intType, required: true, description: "Measurement series ID"},
+			{name: "resolution", in: "query", schema: stringType, description: "quarter, hour (default), day or month"},
+			{name: "from", in: "query", schema: timeType, description: rangeDoc},
+			{name: "to", in: "query", schema: timeType, description: rangeDoc},
+			{name: "includeMissing", in: "query", schema: boolType, description: "Include missing values as null"},
+		},
+		response: reflect.TypeOf(MeasurementsWrapper{}),
+		handle: func(c Client, r *http.Request) (interface{}, error) {
+			id, err := strconv.Atoi(r.PathValue("id"))
+			if err != nil {
+				return nil, badRequest{fmt.Errorf("invalid series ID: %q", r.PathValue("id"))}
+			}
+
+			query := r.URL.Query()
+			resolution := Resolution(query.Get("resolution"))
+			switch resolution {
+			case "":
+				resolution = Hour
+			case Quarter, Hour, Day, Month:
+			default:
+				return nil, badRequest{fmt.Errorf("invalid resolution: %q", resolution)}
+			}
+
+			from, err := queryTime(r, "from")
+			if err != nil {
+				return nil, err
+			}
+			to, err := queryTime(r, "to")
+			if err != nil {
+				return nil, err
+			}
+			includeMissing := false
+			if v := query.Get("includeMissing"); v != "" {
+				if includeMissing, err = strconv.ParseBool(v); err != nil {
+					return nil, badRequest{fmt.Errorf("invalid includeMissing: %q", v)}
+				}
+			}
+
+			return GetMeasurementsChunked(c, id, resolution, from, to, includeMissing)
+		},
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/costs/{installation}",
+		summary: "Get monthly costs of an installation as typed rows",
+		params: []serverParam{
+			{name: "installation", in: "path", schema: stringType, required: true, description: "Installation ID"},
+			{name: "from", in: "query", schema: timeType, description: rangeDoc},
+			{name: "to", in: "query", schema: timeType, description: rangeDoc},
+		},
+		response: reflect.TypeOf(CostRowsWrapper{}),
+		handle: func(c Client, r *http.Request) (interface{}, error) {
+			var from, to *time.Time
+			for name, target := range map[string]**time.Time{"from": &from, "to": &to} {
+				t, err := queryTime(r, name)
+				if err != nil {
+					return nil, err
+				}
+				if !t.IsZero() {
+					*target = &t
+				}
+			}
+
+			costs, err := c.GetCosts(r.PathValue("installation"), from, to)
+			if err != nil {
+				return nil, err
+			}
+			rows, err := CostRows(costs)
+			if err != nil {
+				return nil, err
+			}
+
+			result := CostRowsWrapper{Installation: r.PathValue("installation"), Costs: rows}
+			if len(rows) > 0 {
+				result.Installation, result.EnergyClass = rows[0].Installation, rows[0].EnergyClass
+			}
+			return result, nil
+		},
+	},
+}
+
+// NewServer returns an HTTP handler serving the routes of OpenAPI with the client,
+// and the OpenAPI document itself at /openapi.json
+//
+// Example:
+//
+//	server := eon.NewServer(client, eon.ServerOptions{APIKeys: []string{key}, CacheTTL: 5 * time.Minute})
+//	log.Fatal(http.ListenAndServe(":8080", server))
+func NewServer(c Client, opts ServerOptions) *Server {
+	if opts.Cache == nil {
+		opts.Cache = NewMemoryCache(0)
+	}
+	s := &Server{client: c, opts: opts, mux: http.NewServeMux()}
+
+	for _, route := range serverRoutes {
+		s.mux.HandleFunc(route.method+" "+route.path, s.serve(route))
+	}
+	s.mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, OpenAPI())
+	})
+	return s
+}
+
+// ServeHTTP handles CORS and authentication before routing the request
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && len(s.opts.CORSOrigins) > 0 {
+		if slices.Contains(s.opts.CORSOrigins, "*") {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else if slices.Contains(s.opts.CORSOrigins, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		}
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, X-API-Key, Cache-Control")
+	}
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.URL.Path != "/openapi.json" && !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="eon"`)
+		writeJSON(w, http.StatusUnauthorized, ServerError{Error: "missing or invalid API key"})
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized reports whether the request carries an accepted API key, or no keys are configured
+func (s *Server) authorized(r *http.Request) bool {
+	if len(s.opts.APIKeys) == 0 {
+		return true
+	}
+
+	key := r.Header.Get("X-API-Key")
+	if key == "" {
+		key, _ = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	for _, accepted := range s.opts.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(accepted)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// serve returns the handler of a route. Successful responses are cached by path and query;
+// a Cache-Control: no-cache request header bypasses and refreshes the cache, including the client's.
+func (s *Server) serve(route serverRoute) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.RequestURI()
+		client := s.client
+		if strings.Contains(r.Header.Get("Cache-Control"), "no-cache") {
+			client = WithCacheMode(client, CacheRefresh)
+		} else if s.opts.CacheTTL > 0 {
+			if body, ok := s.opts.Cache.Get(key); ok {
+				w.Header().Set("X-Cache", "HIT")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(body)
+				return
+			}
+		}
+
+		result, err := route.handle(client, r)
+		if err != nil {
+			status := http.StatusBadGateway
+			var invalid badRequest
+			switch {
+			case errors.As(err, &invalid):
+				status = http.StatusBadRequest
+			case errors.Is(err, ErrorNotFound):
+				status = http.StatusNotFound
+			}
+			writeJSON(w, status, ServerError{Error: err.Error()})
+			return
+		}
+
+		body, err := json.Marshal(result)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, ServerError{Error: err.Error()})
+			return
+		}
+		if s.opts.CacheTTL > 0 {
+			s.opts.Cache.Set(key, body, s.opts.CacheTTL)
+			w.Header().Set("X-Cache", "MISS")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
+
+// queryTime parses an optional RFC 3339 or YYYY-MM-DD query parameter
+func queryTime(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, badRequest{fmt.Errorf("invalid %s: %q", name, value)}
+	}
+	return t.UTC(), nil
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// OpenAPI returns the OpenAPI 3 document of the server, generated from the routes and Go types
+func OpenAPI() map[string]interface{} {
+	schemas := openAPISchemas{}
+	errorResponse := map[string]interface{}{
+		"description": "Error",
+		"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": schemas.schema(reflect.TypeOf(ServerError{}))}},
+	}
+
+	paths := map[string]interface{}{}
+	for _, route := range serverRoutes {
+		var params []interface{}
+		for _, p := range route.params {
+			params = append(params, map[string]interface{}{
+				"name":        p.name,
+				"in":          p.in,
+				"required":    p.required,
+				"description": p.description,
+				"schema":      schemas.schema(p.schema),
+			})
+		}
+
+		operation := map[string]interface{}{
+			"summary": route.summary,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": schemas.schema(route.response)}},
+				},
+				"400": errorResponse,
+				"401": errorResponse,
+				"404": errorResponse,
+				"502": errorResponse,
+			},
+		}
+		if params != nil {
+			operation["parameters"] = params
+		}
+
+		item, ok := paths[route.path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[route.path] = item
+		}
+		item[strings.ToLower(route.method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Eon Energy Navigator gateway",
+			"description": "REST gateway over the Eon Energy Navigator API. API keys are only required if configured.",
+			"version":     "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}(schemas),
+			"securitySchemes": map[string]interface{}{
+				"apiKey":     map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []interface{}{
+			map[string]interface{}{"apiKey": []interface{}{}},
+			map[string]interface{}{"bearerAuth": []interface{}{}},
+		},
+	}
+}
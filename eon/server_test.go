@@ -0,0 +1,191 @@
+package eon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve sends a request to the server and records the response
+func serve(s *Server, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w
+}
+
+func TestServer(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	httpmock.RegisterResponder("GET", "/installations",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "inst-1", req.URL.Query().Get("installationFilter"))
+			return httpmock.NewJsonResponse(200, InstallationsWrapper{Installations: []InstallationDto{{ID: "inst-1"}}})
+		})
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/day",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2024-01-01T00:00:00.000Z", req.URL.Query().Get("from"))
+			assert.Equal(t, "true", req.URL.Query().Get("includeMissing"))
+			return httpmock.NewJsonResponse(200, dailySeries(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1, 2))
+		})
+	httpmock.RegisterResponder("GET", "/measurements/500/resolution/hour",
+		httpmock.NewStringResponder(500, "boom"))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"energyClass":  "El",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{"month": "2024-03-01T00:00:00", "retailCost": 300.0},
+			},
+		}))
+
+	server := NewServer(c, ServerOptions{})
+
+	t.Run("serves installations", func(t *testing.T) {
+		w := serve(server, http.MethodGet, "/installations?id=inst-1", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var result InstallationsWrapper
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, "inst-1", result.Installations[0].ID)
+	})
+
+	t.Run("serves measurements", func(t *testing.T) {
+		w := serve(server, http.MethodGet, "/measurements/12345?resolution=day&from=2024-01-01&to=2024-01-02T00:00:00Z&includeMissing=true", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var result MeasurementsWrapper
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Len(t, result.Measurements, 2)
+	})
+
+	t.Run("serves typed costs", func(t *testing.T) {
+		w := serve(server, http.MethodGet, "/costs/inst-1?from=2024-03-01", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var result CostRowsWrapper
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, "El", result.EnergyClass)
+		assert.Equal(t, 300.0, *result.Costs[0].RetailCost)
+	})
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		for _, path := range []string{"/measurements/abc", "/measurements/12345?resolution=week", "/measurements/12345?from=yesterday"} {
+			w := serve(server, http.MethodGet, path, nil)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, path)
+			assert.Contains(t, w.Body.String(), `"error"`)
+		}
+	})
+
+	t.Run("reports upstream errors", func(t *testing.T) {
+		w := serve(server, http.MethodGet, "/measurements/500", nil)
+
+		assert.Equal(t, http.StatusBadGateway, w.Code)
+	})
+
+	t.Run("serves the OpenAPI document", func(t *testing.T) {
+		w := serve(server, http.MethodGet, "/openapi.json", nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"openapi":"3.0.3"`)
+	})
+}
+
+func TestServerAuthentication(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{}))
+
+	server := NewServer(c, ServerOptions{APIKeys: []string{"secret"}, CORSOrigins: []string{"https://app.example.com"}})
+
+	t.Run("requires an API key", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(server, http.MethodGet, "/installations/measurement-series", nil).Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(server, http.MethodGet, "/installations/measurement-series", map[string]string{"X-API-Key": "wrong"}).Code)
+	})
+
+	t.Run("accepts header and bearer keys", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(server, http.MethodGet, "/installations/measurement-series", map[string]string{"X-API-Key": "secret"}).Code)
+		assert.Equal(t, http.StatusOK, serve(server, http.MethodGet, "/installations/measurement-series", map[string]string{"Authorization": "Bearer secret"}).Code)
+	})
+
+	t.Run("leaves the OpenAPI document public", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(server, http.MethodGet, "/openapi.json", nil).Code)
+	})
+
+	t.Run("answers CORS preflight requests", func(t *testing.T) {
+		w := serve(server, http.MethodOptions, "/installations", map[string]string{"Origin": "https://app.example.com"})
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "X-API-Key")
+	})
+
+	t.Run("ignores other origins", func(t *testing.T) {
+		w := serve(server, http.MethodOptions, "/installations", map[string]string{"Origin": "https://other.example.com"})
+
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestServerCache(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{Installations: []InstallationDto{{ID: "inst-1"}}}))
+
+	server := NewServer(c, ServerOptions{CacheTTL: time.Minute})
+
+	assert.Equal(t, "MISS", serve(server, http.MethodGet, "/installations", nil).Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", serve(server, http.MethodGet, "/installations", nil).Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", serve(server, http.MethodGet, "/installations?id=inst-1", nil).Header().Get("X-Cache"))
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	t.Run("refreshes on no-cache", func(t *testing.T) {
+		w := serve(server, http.MethodGet, "/installations", map[string]string{"Cache-Control": "no-cache"})
+
+		assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+		assert.Equal(t, 3, httpmock.GetTotalCallCount())
+	})
+}
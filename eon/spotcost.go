@@ -0,0 +1,178 @@
+package eon
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SpotCost is the estimated spot cost of a single interval. Price and Cost are nil if no price covers it.
+type SpotCost struct {
+	Time        time.Time `json:"time"`
+	Consumption float64   `json:"consumption"` // kWh
+	Price       *float64  `json:"price"`       // Per kWh
+	Cost        *float64  `json:"cost"`
+}
+
+// SpotCostReport holds estimated spot costs per interval and in total.
+//
+// AveragePrice is the consumption-weighted average price paid, and SpotAverage the plain average
+// of the prices of the same intervals; a weighted price below the spot average means consumption
+// was shifted to cheaper hours. Totals only include priced intervals.
+type SpotCostReport struct {
+	SeriesID     int        `json:"seriesId"`
+	Resolution   string     `json:"resolution"`
+	PriceArea    string     `json:"priceArea"`
+	Intervals    []SpotCost `json:"intervals"`
+	Consumption  float64    `json:"consumption"` // kWh of priced intervals
+	Cost         float64    `json:"cost"`
+	AveragePrice float64    `json:"averagePrice"`
+	SpotAverage  float64    `json:"spotAverage"`
+	Unpriced     int        `json:"unpriced"` // Intervals without a price
+}
+
+// GetSpotCosts fetches a quarter or hour series and estimates its spot cost with the prices of the
+// installation's price area (InstallationDto.PriceArea)
+//
+// Example:
+//
+//	prices, _ := eon.LoadPriceFile("nordpool-2024.csv", eon.PriceFileOptions{MWh: true})
+//	report, err := eon.GetSpotCosts(client, 737605, eon.Hour, from, to, prices)
+func GetSpotCosts(c Client, seriesID int, resolution Resolution, from, to time.Time, prices PriceSource) (SpotCostReport, error) {
+	meta, err := GetSeriesMetadata(c, seriesID)
+	if err != nil {
+		return SpotCostReport{}, err
+	}
+	if meta.Installation.PriceArea == "" {
+		return SpotCostReport{}, fmt.Errorf("installation %s has no price area", meta.Installation.ID)
+	}
+
+	measurements, err := c.GetMeasurements(seriesID, resolution, from, to, false)
+	if err != nil {
+		return SpotCostReport{}, err
+	}
+	if measurements.Resolution == "" {
+		measurements.Resolution = string(resolution)
+	}
+
+	return SpotCosts(measurements, meta.Series.Unit, meta.Installation.PriceArea, prices)
+}
+
+// SpotCosts multiplies the consumption of each interval of a quarter or hour series with the
+// spot price of the area. A price covers the time until the next price of the area, at most one hour,
+// so both hourly and quarter-hourly prices are supported; an interval covered by several prices is
+// priced with their time-weighted average, and an interval not fully covered is left unpriced.
+func SpotCosts(m MeasurementsWrapper, unit, area string, prices PriceSource) (SpotCostReport, error) {
+	perHour, err := intervalsPerHour(m.Resolution)
+	if err != nil {
+		return SpotCostReport{}, fmt.Errorf("spot cost: %w", err)
+	}
+	length := time.Duration(float64(time.Hour) / perHour)
+
+	report := SpotCostReport{
+		SeriesID:   m.ID,
+		Resolution: m.Resolution,
+		PriceArea:  normalizePriceArea(area),
+		Intervals:  []SpotCost{},
+	}
+
+	var from, to time.Time
+	for _, measurement := range m.Measurements {
+		ts := measurement.TimeStamp.Time
+		if from.IsZero() || ts.Before(from) {
+			from = ts
+		}
+		if ts.After(to) {
+			to = ts
+		}
+	}
+	if from.IsZero() {
+		return report, nil
+	}
+
+	// Include the price preceding the first interval for quarter series priced hourly
+	series, err := prices.Prices(area, from.Add(-time.Hour), to.Add(length))
+	if err != nil {
+		return SpotCostReport{}, err
+	}
+
+	var priceSum float64
+	for _, measurement := range m.Measurements {
+		if measurement.Value == nil {
+			continue
+		}
+		consumption, err := ToKWh(*measurement.Value, unit)
+		if err != nil {
+			return SpotCostReport{}, err
+		}
+
+		interval := SpotCost{Time: measurement.TimeStamp.Time, Consumption: consumption}
+		if price, ok := priceOver(series, interval.Time, interval.Time.Add(length)); ok {
+			cost := consumption * price
+			interval.Price, interval.Cost = &price, &cost
+
+			report.Consumption += consumption
+			report.Cost += cost
+			priceSum += price
+		} else {
+			report.Unpriced++
+		}
+		report.Intervals = append(report.Intervals, interval)
+	}
+
+	if report.Consumption != 0 {
+		report.AveragePrice = report.Cost / report.Consumption
+	}
+	if priced := len(report.Intervals) - report.Unpriced; priced > 0 {
+		report.SpotAverage = priceSum / float64(priced)
+	}
+
+	return report, nil
+}
+
+// priceOver returns the time-weighted average price over [from, to) from prices sorted by time,
+// or false unless the prices cover the whole interval
+func priceOver(prices []Price, from, to time.Time) (float64, bool) {
+	return averageOver(len(prices), func(i int) (time.Time, float64) { return prices[i].Time, prices[i].Price }, from, to)
+}
+
+// averageOver returns the time-weighted average over [from, to) of n values sorted by time, each
+// valid from its time until the next value and at most one hour, or false unless the values cover
+// the whole interval. at returns the time and value of the value at index i.
+func averageOver(n int, at func(i int) (time.Time, float64), from, to time.Time) (float64, bool) {
+	i := sort.Search(n, func(i int) bool {
+		t, _ := at(i)
+		return t.After(from)
+	}) - 1
+	if i < 0 {
+		return 0, false
+	}
+
+	var sum float64
+	for t := from; t.Before(to); i++ {
+		if i >= n {
+			return 0, false
+		}
+		start, value := at(i)
+		if start.After(t) {
+			return 0, false // Gap between values
+		}
+
+		end := start.Add(time.Hour)
+		if i+1 < n {
+			if next, _ := at(i + 1); next.Before(end) {
+				end = next
+			}
+		}
+		if !t.Before(end) {
+			return 0, false
+		}
+		if to.Before(end) {
+			end = to
+		}
+
+		sum += value * float64(end.Sub(t))
+		t = end
+	}
+	return sum / float64(to.Sub(from)), true
+}
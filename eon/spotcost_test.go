@@ -0,0 +1,106 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpotCosts(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := NewFilePriceSource([]Price{
+		{jan, "SE3", 1},
+		{jan.Add(time.Hour), "SE3", 3},
+		{jan.Add(2 * time.Hour), "SE3", 2},
+	})
+
+	t.Run("weights prices by consumption", func(t *testing.T) {
+		m := hourlySeries(jan, float(1000), float(3000), nil, float(1000))
+
+		report, err := SpotCosts(m, "WH", "SE3", prices)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SE3", report.PriceArea)
+		assert.Len(t, report.Intervals, 3)
+		assert.Equal(t, 9.0, *report.Intervals[1].Cost)
+		assert.Nil(t, report.Intervals[2].Price) // 03:00 isn't covered by the 02:00 price
+		assert.Equal(t, 1, report.Unpriced)
+		assert.Equal(t, 4.0, report.Consumption)
+		assert.Equal(t, 10.0, report.Cost)
+		assert.Equal(t, 2.5, report.AveragePrice)
+		assert.Equal(t, 2.0, report.SpotAverage)
+	})
+
+	t.Run("prices quarters with hourly prices", func(t *testing.T) {
+		m := MeasurementsWrapper{Resolution: "quarter"}
+		for i := 0; i < 8; i++ {
+			m.Measurements = append(m.Measurements, MeasurementDto{TimeStamp: FlexibleTime{Time: jan.Add(time.Duration(i) * 15 * time.Minute)}, Value: float(1)})
+		}
+
+		report, err := SpotCosts(m.Between(jan.Add(30*time.Minute), jan.Add(2*time.Hour)), "KWH", "SE3", prices)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Unpriced)
+		assert.Equal(t, 2*1+4*3.0, report.Cost)
+	})
+
+	t.Run("averages quarter-hourly prices over hours", func(t *testing.T) {
+		quarterly := NewFilePriceSource([]Price{
+			{jan, "SE3", 1},
+			{jan.Add(15 * time.Minute), "SE3", 2},
+			{jan.Add(30 * time.Minute), "SE3", 3},
+			{jan.Add(45 * time.Minute), "SE3", 6},
+			{jan.Add(time.Hour), "SE3", 1},
+		})
+		m := hourlySeries(jan, float(2), float(1))
+
+		report, err := SpotCosts(m, "KWH", "SE3", quarterly)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3.0, *report.Intervals[0].Price)
+		assert.Equal(t, 1.0, *report.Intervals[1].Price) // The last price covers up to an hour
+	})
+
+	t.Run("rejects day resolution", func(t *testing.T) {
+		_, err := SpotCosts(MeasurementsWrapper{Resolution: "day"}, "KWH", "SE3", prices)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetSpotCosts(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{{ID: 1, Unit: "KWH"}}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{{ID: "inst-1", PriceArea: "SE4"}},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, hourlySeries(jan, float(2))))
+
+	report, err := GetSpotCosts(c, 1, Hour, jan, jan.Add(time.Hour), NewFilePriceSource([]Price{{jan, "SE4", 1.5}}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SE4", report.PriceArea)
+	assert.Equal(t, 3.0, report.Cost)
+}
@@ -0,0 +1,197 @@
+package eon
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CostComponentTotal is the sum of a single cost component
+type CostComponentTotal struct {
+	Component string  `json:"component"`
+	Detail    bool    `json:"detail"` // Part of the grid or biogas breakdown, not added to the totals
+	ExclVAT   float64 `json:"exclVAT"`
+	InclVAT   float64 `json:"inclVAT"`
+}
+
+// CostPeriodSummary holds the cost components and totals of a month, quarter, year or the whole range
+type CostPeriodSummary struct {
+	Period       string               `json:"period"`
+	Start        time.Time            `json:"start"`
+	Components   []CostComponentTotal `json:"components"`
+	TotalExclVAT float64              `json:"totalExclVAT"`
+	TotalInclVAT float64              `json:"totalInclVAT"`
+}
+
+// CostSummary holds cost totals per period and the grand totals
+type CostSummary struct {
+	Installation string              `json:"installation"`
+	EnergyClass  string              `json:"energyClass"`
+	Periods      []CostPeriodSummary `json:"periods"`
+	Total        CostPeriodSummary   `json:"total"`
+}
+
+// costComponent locates the fields of a cost component within CostRow
+type costComponent struct {
+	name   string
+	detail bool
+	excl   []int
+	incl   []int
+}
+
+// costComponents lists every cost component of CostRow, pairing each field with its VAT sibling.
+// Columns expanded from the grid and biogas details are marked as detail components.
+var costComponents = func() []costComponent {
+	var components []costComponent
+	floatPtr := reflect.TypeOf((*float64)(nil))
+
+	isDetail := func(name string) bool {
+		for _, details := range []reflect.Type{reflect.TypeOf(CostGridDetailsDto{}), reflect.TypeOf(CostBioGasDetailsDto{})} {
+			if _, ok := details.FieldByName(name); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	t := reflect.TypeOf(CostRow{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type != floatPtr || strings.HasSuffix(f.Name, "VAT") {
+			continue
+		}
+
+		component := costComponent{
+			name:   strings.Split(f.Tag.Get("json"), ",")[0],
+			detail: isDetail(f.Name),
+			excl:   f.Index,
+		}
+		if vat, ok := t.FieldByName(f.Name + "VAT"); ok {
+			component.incl = vat.Index
+		}
+		components = append(components, component)
+	}
+
+	return components
+}()
+
+// SummarizeCosts sums every cost component per month, quarter or year, and over the whole range.
+// Any of the typed cost wrappers, or the generic value returned by GetCosts, is accepted.
+//
+// Fields suffixed with VAT are treated as amounts including VAT; amounts without one count as is
+// including VAT. Totals are the sum of the top-level components (retail, energy tax, net, effect,
+// energy and flow costs); grid and biogas details break those down further and are reported as
+// detail components. Only components with data are included.
+//
+// Example:
+//
+//	costs, _ := client.GetCosts("735999163005019944", &from, &to)
+//	summary, err := eon.SummarizeCosts(costs, eon.PeriodQuarter)
+func SummarizeCosts(costs interface{}, period SummaryPeriod) (CostSummary, error) {
+	rows, err := CostRows(costs)
+	if err != nil {
+		return CostSummary{}, err
+	}
+	return summarizeCostRows(rows, period)
+}
+
+// summarizeCostRows sums cost rows per period
+func summarizeCostRows(rows []CostRow, period SummaryPeriod) (CostSummary, error) {
+	periodOf := func(t time.Time) (string, time.Time) {
+		switch period {
+		case PeriodQuarter:
+			quarter := (int(t.Month())-1)/3 + 1
+			return fmt.Sprintf("%d-Q%d", t.Year(), quarter), time.Date(t.Year(), time.Month(quarter*3-2), 1, 0, 0, 0, 0, t.Location())
+		case PeriodYear:
+			return fmt.Sprintf("%d", t.Year()), time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+		default:
+			return t.Format("2006-01"), time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		}
+	}
+
+	switch period {
+	case PeriodMonth, PeriodQuarter, PeriodYear:
+	default:
+		return CostSummary{}, fmt.Errorf("invalid summary period: %q", period)
+	}
+
+	summary := CostSummary{Periods: []CostPeriodSummary{}}
+
+	// Only report components with data in at least one row
+	var components []costComponent
+	for _, component := range costComponents {
+		for _, row := range rows {
+			if costValue(row, component.excl) != nil || costValue(row, component.incl) != nil {
+				components = append(components, component)
+				break
+			}
+		}
+	}
+
+	newPeriod := func(name string, start time.Time) *CostPeriodSummary {
+		p := &CostPeriodSummary{Period: name, Start: start, Components: make([]CostComponentTotal, len(components))}
+		for i, component := range components {
+			p.Components[i] = CostComponentTotal{Component: component.name, Detail: component.detail}
+		}
+		return p
+	}
+
+	summary.Total = *newPeriod("total", time.Time{})
+	periods := map[string]*CostPeriodSummary{}
+
+	for _, row := range rows {
+		if summary.Installation == "" {
+			summary.Installation = row.Installation
+			summary.EnergyClass = row.EnergyClass
+		}
+
+		name, start := periodOf(row.Month)
+		p, ok := periods[name]
+		if !ok {
+			p = newPeriod(name, start)
+			periods[name] = p
+		}
+		if summary.Total.Start.IsZero() || start.Before(summary.Total.Start) {
+			summary.Total.Start = start
+		}
+
+		for i, component := range components {
+			var excl, incl float64
+			if v := costValue(row, component.excl); v != nil {
+				excl = *v
+			}
+			// Without an amount including VAT, fall back to the one excluding it so that the
+			// total including VAT never drops below the total excluding VAT
+			incl = excl
+			if v := costValue(row, component.incl); v != nil {
+				incl = *v
+			}
+
+			for _, target := range []*CostPeriodSummary{p, &summary.Total} {
+				target.Components[i].ExclVAT += excl
+				target.Components[i].InclVAT += incl
+				if !component.detail {
+					target.TotalExclVAT += excl
+					target.TotalInclVAT += incl
+				}
+			}
+		}
+	}
+
+	for _, p := range periods {
+		summary.Periods = append(summary.Periods, *p)
+	}
+	sort.Slice(summary.Periods, func(i, j int) bool { return summary.Periods[i].Start.Before(summary.Periods[j].Start) })
+
+	return summary, nil
+}
+
+// costValue returns the value of the CostRow field at index, or nil if it is unset
+func costValue(row CostRow, index []int) *float64 {
+	if index == nil {
+		return nil
+	}
+	return reflect.ValueOf(row).FieldByIndex(index).Interface().(*float64)
+}
@@ -0,0 +1,85 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeCosts(t *testing.T) {
+	month := func(m time.Month) CostsBaseDto {
+		return CostsBaseDto{Month: FlexibleTime{time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC)}}
+	}
+
+	costs := CostsElectricityWrapper{
+		CostsWrapper: CostsWrapper{EnergyClass: "El", Installation: "inst-1"},
+		Costs: []CostElectricityProductionDto{
+			{
+				CostsBaseDto:    month(time.January),
+				RetailCost:      float(100),
+				RetailCostVAT:   float(125),
+				NetCost:         float(40),
+				NetCostVAT:      float(50),
+				CostGridDetails: &CostGridDetailsDto{GridEffect: float(30), GridEffectVAT: float(37.5)},
+			},
+			{
+				CostsBaseDto:  month(time.February),
+				RetailCost:    float(80),
+				RetailCostVAT: float(100),
+				EnergyTax:     float(20),
+			},
+			{
+				CostsBaseDto: month(time.April),
+				RetailCost:   float(10),
+			},
+		},
+	}
+
+	t.Run("sums components per month", func(t *testing.T) {
+		summary, err := SummarizeCosts(costs, PeriodMonth)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "inst-1", summary.Installation)
+		assert.Len(t, summary.Periods, 3)
+		assert.Equal(t, "2024-01", summary.Periods[0].Period)
+		assert.Equal(t, 140.0, summary.Periods[0].TotalExclVAT)
+		assert.Equal(t, 175.0, summary.Periods[0].TotalInclVAT)
+
+		// Only components with data, in model order
+		var names []string
+		for _, c := range summary.Total.Components {
+			names = append(names, c.Component)
+		}
+		assert.Equal(t, []string{"retailCost", "energyTax", "netCost", "gridEffect"}, names)
+	})
+
+	t.Run("grid details are not added to totals", func(t *testing.T) {
+		summary, err := SummarizeCosts(costs, PeriodYear)
+
+		assert.NoError(t, err)
+		assert.Len(t, summary.Periods, 1)
+		assert.Equal(t, "2024", summary.Periods[0].Period)
+		assert.Equal(t, 250.0, summary.Total.TotalExclVAT)
+		assert.Equal(t, 305.0, summary.Total.TotalInclVAT) // Energy tax and April lack VAT amounts
+		assert.Equal(t, CostComponentTotal{Component: "gridEffect", Detail: true, ExclVAT: 30, InclVAT: 37.5}, summary.Total.Components[3])
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), summary.Total.Start)
+	})
+
+	t.Run("groups by quarter", func(t *testing.T) {
+		summary, err := SummarizeCosts(costs, PeriodQuarter)
+
+		assert.NoError(t, err)
+		assert.Len(t, summary.Periods, 2)
+		assert.Equal(t, "2024-Q1", summary.Periods[0].Period)
+		assert.Equal(t, 240.0, summary.Periods[0].TotalExclVAT)
+		assert.Equal(t, "2024-Q2", summary.Periods[1].Period)
+		assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), summary.Periods[1].Start)
+	})
+
+	t.Run("rejects invalid period", func(t *testing.T) {
+		_, err := SummarizeCosts(costs, SummaryPeriod("week"))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,158 @@
+package eon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Telemetry instruments the client with tracing and metrics, e.g. by adapting OpenTelemetry,
+// without the client depending on a telemetry library
+type Telemetry interface {
+	// StartSpan starts a span as a child of the span in ctx and returns a context holding it
+	StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span)
+	// RecordCall records the metrics of a completed API call
+	RecordCall(ctx context.Context, call CallMetrics)
+}
+
+// Span is a span started by Telemetry
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attributes map[string]interface{})
+	// End ends the span, marking it failed if err isn't nil
+	End(err error)
+}
+
+// CallMetrics are the metrics of an API call, including its retries
+type CallMetrics struct {
+	Endpoint    string        // Client method, e.g. GetMeasurements, or Token for token refreshes
+	Status      int           // HTTP status of the last attempt, zero without response
+	Duration    time.Duration // Duration of the call including retries
+	Retries     int           // Number of retried attempts
+	RateLimited int           // Number of 429 responses, including retried ones
+	Err         error         // Error of the call, including unexpected statuses
+}
+
+// Span attributes set by the client
+const (
+	AttributeEndpoint       = "eon.endpoint"
+	AttributeSeriesID       = "eon.series_id"
+	AttributeResolution     = "eon.resolution"
+	AttributeInstallationID = "eon.installation_id"
+	AttributeRetries        = "eon.retries"
+	AttributeCache          = "eon.cache"
+	AttributeStatus         = "http.response.status_code"
+)
+
+// WithTelemetry creates a span per API call and token refresh, as children of the span in the
+// client's context (see WithContext), and records the metrics of each call
+//
+// Example:
+//
+//	client := eon.New(eon.WithTelemetry(otelTelemetry{tracer, meter}))
+//	installations, err := eon.WithContext(client, ctx).GetInstallations(nil)
+func WithTelemetry(telemetry Telemetry) Option {
+	return func(c *client) {
+		c.telemetry = telemetry
+
+		c.resty.AddRetryHook(func(res *resty.Response, err error) {
+			if res == nil {
+				return
+			}
+			// Also called after the last attempt, so 429s are counted by attempt
+			if call := callOf(res.Request.Context()); call != nil && res.StatusCode() == http.StatusTooManyRequests {
+				call.rateLimited++
+				call.rateLimitedAttempt = res.Request.Attempt
+			}
+		})
+		c.resty.OnSuccess(func(_ *resty.Client, res *resty.Response) {
+			if call := callOf(res.Request.Context()); call != nil {
+				if cache := res.Header().Get("X-Cache"); cache != "" {
+					call.span.SetAttributes(map[string]interface{}{AttributeCache: cache})
+				}
+				call.end(res.Request.Context(), res.Request.Attempt, res.StatusCode(), nil)
+			}
+		})
+		c.resty.OnError(func(req *resty.Request, err error) {
+			if call := callOf(req.Context()); call != nil {
+				status := 0
+				var resErr *resty.ResponseError
+				if errors.As(err, &resErr) && resErr.Response.RawResponse != nil {
+					status = resErr.Response.StatusCode()
+				}
+				call.end(req.Context(), req.Attempt, status, err)
+			}
+		})
+	}
+}
+
+// callKey is the context key of the apiCall of a request
+type callKey struct{}
+
+// apiCall is an instrumented API call in progress
+type apiCall struct {
+	telemetry          Telemetry
+	endpoint           string
+	span               Span
+	start              time.Time
+	rateLimited        int
+	rateLimitedAttempt int // Last attempt counted in rateLimited
+}
+
+// startCall starts the span of an API call, returning a nil call if the client has no telemetry
+func (c *client) startCall(ctx context.Context, endpoint string, attributes map[string]interface{}) (context.Context, *apiCall) {
+	if c.telemetry == nil {
+		return ctx, nil
+	}
+
+	spanAttributes := map[string]interface{}{AttributeEndpoint: endpoint}
+	for k, v := range attributes {
+		spanAttributes[k] = v
+	}
+	ctx, span := c.telemetry.StartSpan(ctx, "eon."+endpoint, spanAttributes)
+
+	call := &apiCall{telemetry: c.telemetry, endpoint: endpoint, span: span, start: time.Now()}
+	return context.WithValue(ctx, callKey{}, call), call
+}
+
+// callOf returns the API call of a request context, if instrumented
+func callOf(ctx context.Context) *apiCall {
+	call, _ := ctx.Value(callKey{}).(*apiCall)
+	return call
+}
+
+// end ends the span of the call after attempts and records its metrics. Statuses of 400 and above
+// fail the call.
+func (a *apiCall) end(ctx context.Context, attempts, status int, err error) {
+	if a == nil {
+		return
+	}
+
+	retries := max(attempts-1, 0)
+	if status == http.StatusTooManyRequests && a.rateLimitedAttempt != attempts {
+		a.rateLimited++
+	}
+	if err == nil && status >= http.StatusBadRequest {
+		err = fmt.Errorf("unexpected status %d", status)
+	}
+
+	attributes := map[string]interface{}{AttributeRetries: retries}
+	if status != 0 {
+		attributes[AttributeStatus] = status
+	}
+	a.span.SetAttributes(attributes)
+	a.span.End(err)
+
+	a.telemetry.RecordCall(ctx, CallMetrics{
+		Endpoint:    a.endpoint,
+		Status:      status,
+		Duration:    time.Since(a.start),
+		Retries:     retries,
+		RateLimited: a.rateLimited,
+		Err:         err,
+	})
+}
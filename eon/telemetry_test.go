@@ -0,0 +1,157 @@
+package eon
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordedSpan is a span recorded by recordingTelemetry
+type recordedSpan struct {
+	name       string
+	parent     string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttributes(attributes map[string]interface{}) {
+	for k, v := range attributes {
+		s.attributes[k] = v
+	}
+}
+
+func (s *recordedSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+// spanNameKey is the context key of the name of the current recorded span
+type spanNameKey struct{}
+
+// recordingTelemetry records spans and call metrics
+type recordingTelemetry struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+	calls []CallMetrics
+}
+
+func (r *recordingTelemetry) StartSpan(ctx context.Context, name string, attributes map[string]interface{}) (context.Context, Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parent, _ := ctx.Value(spanNameKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attributes: attributes}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanNameKey{}, name), span
+}
+
+func (r *recordingTelemetry) RecordCall(ctx context.Context, call CallMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, call)
+}
+
+func TestWithTelemetry(t *testing.T) {
+	mockResty := resty.New()
+	mockAuth := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	httpmock.ActivateNonDefault(mockAuth.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	telemetry := &recordingTelemetry{}
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		resty:        mockResty,
+		auth:         mockAuth,
+	}
+	WithTelemetry(telemetry)(c)
+	WithRetries(2, time.Millisecond)(c)
+
+	httpmock.RegisterResponder("POST", tokenEndpoint,
+		httpmock.NewJsonResponderOrPanic(200, OAuth2TokenResponse{AccessToken: "fake-token", ExpiresIn: 3600}))
+
+	attempts := 0
+	httpmock.RegisterResponder("GET", "/measurements/12345/resolution/hour",
+		func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return httpmock.NewStringResponse(429, "slow down"), nil
+			}
+			return httpmock.NewJsonResponse(200, hourlySeries(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), float(1)))
+		})
+	httpmock.RegisterResponder("GET", "/costs/inst-1", httpmock.NewStringResponder(404, "not found"))
+
+	ctx := context.WithValue(context.Background(), spanNameKey{}, "caller")
+
+	t.Run("creates spans linked to the caller", func(t *testing.T) {
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		_, err := WithContext(c, ctx).GetMeasurements(12345, Hour, from, from.Add(time.Hour), false)
+		assert.NoError(t, err)
+
+		assert.Len(t, telemetry.spans, 2)
+		call, token := telemetry.spans[0], telemetry.spans[1]
+
+		assert.Equal(t, "eon.GetMeasurements", call.name)
+		assert.Equal(t, "caller", call.parent)
+		assert.True(t, call.ended)
+		assert.NoError(t, call.err)
+		assert.Equal(t, "GetMeasurements", call.attributes[AttributeEndpoint])
+		assert.Equal(t, 12345, call.attributes[AttributeSeriesID])
+		assert.Equal(t, "hour", call.attributes[AttributeResolution])
+		assert.Equal(t, 200, call.attributes[AttributeStatus])
+		assert.Equal(t, 1, call.attributes[AttributeRetries])
+
+		assert.Equal(t, "eon.Token", token.name)
+		assert.Equal(t, "eon.GetMeasurements", token.parent)
+		assert.True(t, token.ended)
+	})
+
+	t.Run("records call metrics", func(t *testing.T) {
+		assert.Len(t, telemetry.calls, 2)
+		token, call := telemetry.calls[0], telemetry.calls[1]
+
+		assert.Equal(t, "Token", token.Endpoint)
+		assert.Equal(t, 200, token.Status)
+
+		assert.Equal(t, "GetMeasurements", call.Endpoint)
+		assert.Equal(t, 200, call.Status)
+		assert.Equal(t, 1, call.Retries)
+		assert.Equal(t, 1, call.RateLimited)
+		assert.NoError(t, call.Err)
+		assert.Positive(t, call.Duration)
+	})
+
+	t.Run("fails spans on error statuses", func(t *testing.T) {
+		_, err := c.GetCosts("inst-1", nil, nil)
+		assert.Error(t, err)
+
+		span := telemetry.spans[len(telemetry.spans)-1]
+		assert.Equal(t, "eon.GetCosts", span.name)
+		assert.Equal(t, "inst-1", span.attributes[AttributeInstallationID])
+		assert.Error(t, span.err)
+
+		call := telemetry.calls[len(telemetry.calls)-1]
+		assert.Equal(t, 404, call.Status)
+		assert.Error(t, call.Err)
+	})
+}
+
+func TestWithContext(t *testing.T) {
+	c := &client{}
+	ctx := context.WithValue(context.Background(), spanNameKey{}, "caller")
+
+	derived := WithContext(WithCacheMode(c, CacheRefresh), ctx).(*client)
+
+	assert.Equal(t, "caller", derived.ctx.Value(spanNameKey{}))
+	assert.Equal(t, CacheRefresh, derived.ctx.Value(cacheModeKey{}))
+	assert.Same(t, c, derived.root)
+}
@@ -0,0 +1,214 @@
+package eon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UnitPricePeriod holds the effective price per unit of consumption for a month or the whole range.
+// Components hold the price per unit of each cost component.
+type UnitPricePeriod struct {
+	Period       string               `json:"period"`
+	Start        time.Time            `json:"start"`
+	Consumption  float64              `json:"consumption"`
+	Components   []CostComponentTotal `json:"components"`
+	TotalExclVAT float64              `json:"totalExclVAT"`
+	TotalInclVAT float64              `json:"totalInclVAT"`
+}
+
+// UnitPriceReport holds effective unit prices per month and over the whole range
+type UnitPriceReport struct {
+	Installation string            `json:"installation"`
+	SeriesID     int               `json:"seriesId"`
+	Unit         string            `json:"unit"`
+	Months       []UnitPricePeriod `json:"months"`
+	Total        UnitPricePeriod   `json:"total"`
+}
+
+// IsConsumptionSeries reports whether a measurement series looks like an energy consumption series,
+// i.e. it is measured in (k/M/G)Wh and isn't a production or reactive series.
+func IsConsumptionSeries(series MeasurementSeriesDto) bool {
+	seriesType := strings.ToLower(series.SeriesType)
+	if strings.Contains(seriesType, "production") || strings.Contains(seriesType, "reactive") {
+		return false
+	}
+	return strings.HasSuffix(strings.ToUpper(series.Unit), "WH")
+}
+
+// FindConsumptionSeries returns the first consumption series of an installation
+func FindConsumptionSeries(c Client, installationID string) (MeasurementSeriesDto, error) {
+	series, err := c.GetMeasurementSeries()
+	if err != nil {
+		return MeasurementSeriesDto{}, err
+	}
+
+	for _, inst := range series.Installations {
+		if inst.ID != installationID {
+			continue
+		}
+		for _, ms := range inst.MeasurementSeries {
+			if IsConsumptionSeries(ms) {
+				return ms, nil
+			}
+		}
+	}
+
+	return MeasurementSeriesDto{}, fmt.Errorf("consumption series for installation %s: %w", installationID, ErrorNotFound)
+}
+
+// GetUnitPrices fetches monthly costs and consumption of an installation and computes the effective
+// price per unit, in total and per cost component, excluding and including VAT.
+// The consumption series is detected with FindConsumptionSeries unless seriesID is non-zero,
+// in which case the series must belong to the installation.
+// Energy is reported per kWh, other series in their own unit.
+// Months without both costs and consumption are left out.
+//
+// Example:
+//
+//	report, err := eon.GetUnitPrices(client, "735999163005019944", 0, &from, &to)
+func GetUnitPrices(c Client, installationID string, seriesID int, from, to *time.Time) (UnitPriceReport, error) {
+	series, err := consumptionSeries(c, installationID, seriesID)
+	if err != nil {
+		return UnitPriceReport{}, err
+	}
+	return getUnitPrices(c, installationID, series, from, to)
+}
+
+// consumptionSeries returns the series seriesID, which must belong to the installation,
+// or detects the consumption series of the installation if zero
+func consumptionSeries(c Client, installationID string, seriesID int) (MeasurementSeriesDto, error) {
+	if seriesID == 0 {
+		return FindConsumptionSeries(c, installationID)
+	}
+	meta, err := GetSeriesMetadata(c, seriesID)
+	if err != nil {
+		return MeasurementSeriesDto{}, err
+	}
+	if meta.Installation.ID != installationID {
+		return MeasurementSeriesDto{}, fmt.Errorf("series %d belongs to installation %s, not %s", seriesID, meta.Installation.ID, installationID)
+	}
+	return meta.Series, nil
+}
+
+// getUnitPrices computes the unit prices of an installation with the consumption of series
+func getUnitPrices(c Client, installationID string, series MeasurementSeriesDto, from, to *time.Time) (UnitPriceReport, error) {
+	costs, err := c.GetCosts(installationID, from, to)
+	if err != nil {
+		return UnitPriceReport{}, err
+	}
+
+	var mFrom, mTo time.Time
+	if from != nil {
+		mFrom = *from
+	}
+	if to != nil {
+		mTo = *to
+	}
+	measurements, err := c.GetMeasurements(series.ID, Month, mFrom, mTo, false)
+	if err != nil {
+		return UnitPriceReport{}, err
+	}
+	measurements, unit := inKWh(measurements, series.Unit)
+
+	report, err := UnitPrices(costs, measurements)
+	if err != nil {
+		return UnitPriceReport{}, err
+	}
+	report.Unit = unit
+	return report, nil
+}
+
+// inKWh converts measurements of an energy unit to kWh and returns them with their new unit.
+// Measurements of other units are returned unchanged.
+func inKWh(m MeasurementsWrapper, unit string) (MeasurementsWrapper, string) {
+	if _, err := ToKWh(0, unit); err != nil {
+		return m, unit
+	}
+
+	converted := make([]MeasurementDto, len(m.Measurements))
+	for i, measurement := range m.Measurements {
+		if measurement.Value != nil {
+			kWh, _ := ToKWh(*measurement.Value, unit)
+			measurement.Value = &kWh
+		}
+		converted[i] = measurement
+	}
+	m.Measurements = converted
+	return m, "kWh"
+}
+
+// UnitPrices computes effective unit prices from the result of GetCosts and monthly measurements.
+// Months without both costs and consumption are left out.
+func UnitPrices(costs interface{}, measurements MeasurementsWrapper) (UnitPriceReport, error) {
+	summary, err := SummarizeCosts(costs, PeriodMonth)
+	if err != nil {
+		return UnitPriceReport{}, err
+	}
+
+	consumption := map[string]float64{}
+	for _, m := range measurements.Measurements {
+		if m.Value != nil {
+			consumption[m.TimeStamp.Format("2006-01")] += *m.Value
+		}
+	}
+
+	report := UnitPriceReport{
+		Installation: summary.Installation,
+		SeriesID:     measurements.ID,
+		Months:       []UnitPricePeriod{},
+	}
+
+	// Only sum costs of months that also have consumption
+	matched := CostPeriodSummary{Period: "total", Components: make([]CostComponentTotal, len(summary.Total.Components))}
+	copy(matched.Components, summary.Total.Components)
+	for i := range matched.Components {
+		matched.Components[i].ExclVAT, matched.Components[i].InclVAT = 0, 0
+	}
+	var totalConsumption float64
+
+	for _, p := range summary.Periods {
+		units := consumption[p.Period]
+		if units == 0 {
+			continue
+		}
+
+		report.Months = append(report.Months, perUnit(p, units))
+
+		if matched.Start.IsZero() {
+			matched.Start = p.Start
+		}
+		for i, component := range p.Components {
+			matched.Components[i].ExclVAT += component.ExclVAT
+			matched.Components[i].InclVAT += component.InclVAT
+		}
+		matched.TotalExclVAT += p.TotalExclVAT
+		matched.TotalInclVAT += p.TotalInclVAT
+		totalConsumption += units
+	}
+
+	report.Total = UnitPricePeriod{Period: "total", Components: []CostComponentTotal{}}
+	if totalConsumption != 0 {
+		report.Total = perUnit(matched, totalConsumption)
+	}
+
+	return report, nil
+}
+
+// perUnit divides the costs of a period by its consumption
+func perUnit(p CostPeriodSummary, units float64) UnitPricePeriod {
+	result := UnitPricePeriod{
+		Period:       p.Period,
+		Start:        p.Start,
+		Consumption:  units,
+		Components:   make([]CostComponentTotal, len(p.Components)),
+		TotalExclVAT: p.TotalExclVAT / units,
+		TotalInclVAT: p.TotalInclVAT / units,
+	}
+	for i, component := range p.Components {
+		component.ExclVAT /= units
+		component.InclVAT /= units
+		result.Components[i] = component
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package eon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsConsumptionSeries(t *testing.T) {
+	assert.True(t, IsConsumptionSeries(MeasurementSeriesDto{SeriesType: "ElectricActive", Unit: "KWH"}))
+	assert.True(t, IsConsumptionSeries(MeasurementSeriesDto{SeriesType: "Heat", Unit: "MWh"}))
+	assert.False(t, IsConsumptionSeries(MeasurementSeriesDto{SeriesType: "ElectricActiveProduction", Unit: "KWH"}))
+	assert.False(t, IsConsumptionSeries(MeasurementSeriesDto{SeriesType: "ElectricReactive", Unit: "KVARH"}))
+	assert.False(t, IsConsumptionSeries(MeasurementSeriesDto{SeriesType: "Flow", Unit: "M3"}))
+}
+
+func TestUnitPrices(t *testing.T) {
+	costs := CostsElectricityWrapper{
+		CostsWrapper: CostsWrapper{EnergyClass: "El", Installation: "inst-1"},
+		Costs: []CostElectricityProductionDto{
+			{
+				CostsBaseDto:  CostsBaseDto{Month: FlexibleTime{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+				RetailCost:    float(100),
+				RetailCostVAT: float(125),
+				NetCost:       float(50),
+				NetCostVAT:    float(62.5),
+			},
+			{
+				CostsBaseDto: CostsBaseDto{Month: FlexibleTime{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}},
+				RetailCost:   float(200),
+			},
+			{
+				CostsBaseDto: CostsBaseDto{Month: FlexibleTime{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}},
+				RetailCost:   float(999),
+			},
+		},
+	}
+
+	measurements := MeasurementsWrapper{
+		ID:         737605,
+		Resolution: "month",
+		Measurements: []MeasurementDto{
+			{TimeStamp: FlexibleTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Value: float(100)},
+			{TimeStamp: FlexibleTime{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}, Value: float(400)},
+			{TimeStamp: FlexibleTime{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, Value: nil},
+		},
+	}
+
+	report, err := UnitPrices(costs, measurements)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "inst-1", report.Installation)
+	assert.Len(t, report.Months, 2) // March has no consumption
+
+	assert.Equal(t, 1.5, report.Months[0].TotalExclVAT)
+	assert.Equal(t, 1.875, report.Months[0].TotalInclVAT)
+	assert.Equal(t, 1.0, report.Months[0].Components[0].ExclVAT)
+	assert.Equal(t, 0.5, report.Months[1].TotalExclVAT)
+
+	assert.Equal(t, 500.0, report.Total.Consumption)
+	assert.Equal(t, 0.7, report.Total.TotalExclVAT)
+	assert.Equal(t, 0.6, report.Total.Components[0].ExclVAT)
+}
+
+func TestGetUnitPrices(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{
+					ID: "inst-1",
+					MeasurementSeries: []MeasurementSeriesDto{
+						{ID: 1, SeriesType: "ElectricReactive", Unit: "KVARH"},
+						{ID: 2, SeriesType: "ElectricActive", Unit: "KWH"},
+						{ID: 3, SeriesType: "ElectricActive", Unit: "MWH"},
+					},
+				},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"energyClass":  "El",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{"month": "2024-01-01T00:00:00", "retailCost": 50.0},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{Installations: []InstallationDto{{ID: "inst-1"}}}))
+	httpmock.RegisterResponder("GET", "/measurements/3/resolution/month",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         3,
+			Resolution: "month",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Value: float(200)},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/2/resolution/month",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         2,
+			Resolution: "month",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Value: float(200)},
+			},
+		}))
+
+	t.Run("detects consumption series", func(t *testing.T) {
+		report, err := GetUnitPrices(c, "inst-1", 0, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.SeriesID)
+		assert.Equal(t, "kWh", report.Unit)
+		assert.Equal(t, 0.25, report.Total.TotalExclVAT)
+	})
+
+	t.Run("converts a given series to kWh", func(t *testing.T) {
+		report, err := GetUnitPrices(c, "inst-1", 3, nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, report.SeriesID)
+		assert.Equal(t, "kWh", report.Unit)
+		assert.Equal(t, 200000.0, report.Total.Consumption)
+		assert.Equal(t, 0.00025, report.Total.TotalExclVAT)
+	})
+
+	t.Run("returns ErrorNotFound without consumption series", func(t *testing.T) {
+		_, err := GetUnitPrices(c, "inst-2", 0, nil, nil)
+
+		assert.ErrorIs(t, err, ErrorNotFound)
+	})
+
+	t.Run("rejects a series of another installation", func(t *testing.T) {
+		_, err := GetUnitPrices(c, "inst-2", 3, nil, nil)
+
+		assert.ErrorContains(t, err, "series 3 belongs to installation inst-1")
+	})
+}
@@ -0,0 +1,309 @@
+package eon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AlertRetention is how long sent alerts are remembered by AlertState
+const AlertRetention = 90 * 24 * time.Hour
+
+// Duration is a time.Duration encoded in JSON as a Go duration string such as "36h"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid duration %s: expected a string such as \"36h\"", b)
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Rule is a condition evaluated by Watcher
+type Rule struct {
+	Name         string   `json:"name"` // Identifies the rule in alerts, derived from type and subject if empty
+	Type         RuleType `json:"type"`
+	SeriesID     int      `json:"seriesId"`     // Series of consumption and stale rules
+	Installation string   `json:"installation"` // Installation of monthlyCost rules
+	Threshold    float64  `json:"threshold"`    // kWh per hour for consumption rules, budget for monthlyCost rules
+	IncludeVAT   bool     `json:"includeVAT"`   // Compare the monthly cost including VAT
+	Lookback     Duration `json:"lookback"`     // Hours checked by consumption rules, 24h if zero
+	MaxAge       Duration `json:"maxAge"`       // Maximum age of the last update for stale rules
+}
+
+// Alert is a rule violation. Key identifies the violation, so that it is only notified once.
+type Alert struct {
+	Key       string    `json:"key"`
+	Rule      string    `json:"rule"`
+	Type      RuleType  `json:"type"`
+	Subject   string    `json:"subject"` // Series ID or installation
+	Time      time.Time `json:"time"`    // Hour, month or last update the alert is about
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Message   string    `json:"message"`
+}
+
+// name returns the rule name, or one derived from its type and subject
+func (r Rule) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if r.Type == RuleMonthlyCost {
+		return fmt.Sprintf("%s-%s", r.Type, r.Installation)
+	}
+	return fmt.Sprintf("%s-%d", r.Type, r.SeriesID)
+}
+
+// Evaluate checks the rule at now and returns its violations
+func (r Rule) Evaluate(c Client, now time.Time) ([]Alert, error) {
+	name := r.name()
+	alert := func(key string, t time.Time, value float64, message string) Alert {
+		subject := r.Installation
+		if r.Type != RuleMonthlyCost {
+			subject = fmt.Sprint(r.SeriesID)
+		}
+		return Alert{
+			Key:       name + "/" + key,
+			Rule:      name,
+			Type:      r.Type,
+			Subject:   subject,
+			Time:      t,
+			Value:     value,
+			Threshold: r.Threshold,
+			Message:   message,
+		}
+	}
+
+	switch r.Type {
+	case RuleConsumption:
+		meta, err := GetSeriesMetadata(c, r.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+
+		lookback := time.Duration(r.Lookback)
+		if lookback == 0 {
+			lookback = 24 * time.Hour
+		}
+		measurements, err := c.GetMeasurements(r.SeriesID, Hour, now.Add(-lookback).UTC(), now.UTC(), false)
+		if err != nil {
+			return nil, err
+		}
+
+		var alerts []Alert
+		for _, m := range measurements.Measurements {
+			if m.Value == nil {
+				continue
+			}
+			kwh, err := ToKWh(*m.Value, meta.Series.Unit)
+			if err != nil {
+				return nil, err
+			}
+			if kwh > r.Threshold {
+				ts := m.TimeStamp.Time
+				alerts = append(alerts, alert(ts.UTC().Format(time.RFC3339), ts, kwh,
+					fmt.Sprintf("series %d consumed %.2f kWh in the hour from %s, above %.2f kWh", r.SeriesID, kwh, ts.Format(time.RFC3339), r.Threshold)))
+			}
+		}
+		return alerts, nil
+
+	case RuleMonthlyCost:
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		costs, err := c.GetCosts(r.Installation, &month, &now)
+		if err != nil {
+			return nil, err
+		}
+		summary, err := SummarizeCosts(costs, PeriodMonth)
+		if err != nil {
+			return nil, err
+		}
+
+		cost := summary.Total.TotalExclVAT
+		if r.IncludeVAT {
+			cost = summary.Total.TotalInclVAT
+		}
+		if cost <= r.Threshold {
+			return nil, nil
+		}
+		return []Alert{alert(month.Format("2006-01"), month, cost,
+			fmt.Sprintf("installation %s cost %.2f in %s, above the budget of %.2f", r.Installation, cost, month.Format("2006-01"), r.Threshold))}, nil
+
+	case RuleStale:
+		if r.MaxAge <= 0 {
+			return nil, fmt.Errorf("maxAge is required for stale rules")
+		}
+		meta, err := GetSeriesMetadata(c, r.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+
+		last := meta.Series.LastUpdate.Time
+		age := now.Sub(last)
+		if age <= time.Duration(r.MaxAge) {
+			return nil, nil
+		}
+		a := alert(last.UTC().Format(time.RFC3339), last, age.Hours(),
+			fmt.Sprintf("series %d was last updated %s ago at %s, more than %s", r.SeriesID, age.Round(time.Minute), last.Format(time.RFC3339), time.Duration(r.MaxAge)))
+		a.Threshold = time.Duration(r.MaxAge).Hours()
+		return []Alert{a}, nil
+
+	default:
+		return nil, fmt.Errorf("invalid rule type: %q", r.Type)
+	}
+}
+
+// AlertState records when alerts were sent, so that they aren't repeated
+type AlertState struct {
+	path   string
+	Alerts map[string]time.Time `json:"alerts"`
+}
+
+// LoadAlertState reads the state file at path. A missing file yields an empty state,
+// and an empty path a state that is only kept in memory.
+func LoadAlertState(path string) (*AlertState, error) {
+	state := &AlertState{path: path, Alerts: map[string]time.Time{}}
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to read alert state %s: %w", path, err)
+	}
+	if state.Alerts == nil {
+		state.Alerts = map[string]time.Time{}
+	}
+	return state, nil
+}
+
+// Seen reports whether an alert with key has been sent
+func (s *AlertState) Seen(key string) bool {
+	_, ok := s.Alerts[key]
+	return ok
+}
+
+// Mark records that an alert with key was sent at t
+func (s *AlertState) Mark(key string, t time.Time) {
+	s.Alerts[key] = t
+}
+
+// Prune forgets alerts sent before t
+func (s *AlertState) Prune(t time.Time) {
+	for key, sent := range s.Alerts {
+		if sent.Before(t) {
+			delete(s.Alerts, key)
+		}
+	}
+}
+
+// Save writes the state file, replacing it atomically
+func (s *AlertState) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Watcher evaluates rules and notifies about new violations
+type Watcher struct {
+	Client    Client
+	Rules     []Rule
+	Notifiers []Notifier
+	State     *AlertState // In-memory state if nil
+}
+
+// Check evaluates every rule at now and sends alerts that weren't sent before through every notifier.
+// An alert counts as sent once every notifier accepted it; until then it is retried through all
+// notifiers on the next check.
+// It returns the newly sent alerts; failing rules and notifiers don't stop the others and are
+// joined into the returned error.
+//
+// Example:
+//
+//	state, _ := eon.LoadAlertState("eon-state.json")
+//	w := &eon.Watcher{Client: client, Rules: rules, Notifiers: notifiers, State: state}
+//	alerts, err := w.Check(time.Now())
+func (w *Watcher) Check(now time.Time) ([]Alert, error) {
+	if w.State == nil {
+		w.State, _ = LoadAlertState("")
+	}
+
+	var errs []error
+	sent := []Alert{}
+	for _, rule := range w.Rules {
+		alerts, err := rule.Evaluate(w.Client, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.name(), err))
+			continue
+		}
+
+		for _, alert := range alerts {
+			if w.State.Seen(alert.Key) {
+				continue
+			}
+
+			delivered := true
+			for _, n := range w.Notifiers {
+				if err := n.Notify(alert); err != nil {
+					errs = append(errs, fmt.Errorf("alert %s: %w", alert.Key, err))
+					delivered = false
+				}
+			}
+			if delivered {
+				w.State.Mark(alert.Key, now)
+				sent = append(sent, alert)
+			}
+		}
+	}
+
+	w.State.Prune(now.Add(-AlertRetention))
+	if err := w.State.Save(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return sent, errors.Join(errs...)
+}
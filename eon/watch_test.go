@@ -0,0 +1,189 @@
+package eon
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingNotifier collects alerts and optionally fails
+type recordingNotifier struct {
+	alerts []Alert
+	err    error
+}
+
+func (n *recordingNotifier) Notify(alert Alert) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func TestDuration(t *testing.T) {
+	var rule Rule
+	err := json.Unmarshal([]byte(`{"maxAge": "36h", "lookback": ""}`), &rule)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Duration(36*time.Hour), rule.MaxAge)
+	assert.Equal(t, Duration(0), rule.Lookback)
+
+	data, err := json.Marshal(Duration(90 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, `"1h30m0s"`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"maxAge": 5}`), &rule))
+}
+
+func TestWatcher(t *testing.T) {
+	mockResty := resty.New()
+	httpmock.ActivateNonDefault(mockResty.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	c := &client{
+		clientID:     "test-client-id",
+		clientSecret: "test-client-secret",
+		accessToken:  "fake-token",
+		tokenExpiry:  time.Now().Add(1 * time.Hour), // Set valid token expiry
+		resty:        mockResty,
+	}
+
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	httpmock.RegisterResponder("GET", "/installations/measurement-series",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsMeasurementsWrapper{
+			Installations: []InstallationMeasurementsDto{
+				{ID: "inst-1", MeasurementSeries: []MeasurementSeriesDto{
+					{ID: 1, Unit: "WH", LastUpdate: FlexibleTime{Time: now.Add(-48 * time.Hour)}},
+				}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/installations",
+		httpmock.NewJsonResponderOrPanic(200, InstallationsWrapper{
+			Installations: []InstallationDto{{ID: "inst-1"}},
+		}))
+	httpmock.RegisterResponder("GET", "/measurements/1/resolution/hour",
+		httpmock.NewJsonResponderOrPanic(200, MeasurementsWrapper{
+			ID:         1,
+			Resolution: "hour",
+			Measurements: []MeasurementDto{
+				{TimeStamp: FlexibleTime{Time: now.Add(-2 * time.Hour)}, Value: float(4000)},
+				{TimeStamp: FlexibleTime{Time: now.Add(-time.Hour)}, Value: float(6000)},
+				{TimeStamp: FlexibleTime{Time: now}},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "/costs/inst-1",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"energyClass":  "El",
+			"installation": "inst-1",
+			"costs": []interface{}{
+				map[string]interface{}{"month": "2024-03-01T00:00:00", "retailCost": 800.0, "retailCostVAT": 1000.0},
+			},
+		}))
+
+	rules := []Rule{
+		{Type: RuleConsumption, SeriesID: 1, Threshold: 5},
+		{Type: RuleMonthlyCost, Installation: "inst-1", Threshold: 900},
+		{Name: "vat-budget", Type: RuleMonthlyCost, Installation: "inst-1", Threshold: 900, IncludeVAT: true},
+		{Type: RuleStale, SeriesID: 1, MaxAge: Duration(24 * time.Hour)},
+	}
+
+	t.Run("evaluates rules", func(t *testing.T) {
+		alerts, err := rules[0].Evaluate(c, now)
+		assert.NoError(t, err)
+		if assert.Len(t, alerts, 1) {
+			assert.Equal(t, "consumption-1/2024-03-15T11:00:00Z", alerts[0].Key)
+			assert.Equal(t, 6.0, alerts[0].Value)
+			assert.Equal(t, "1", alerts[0].Subject)
+		}
+
+		alerts, err = rules[1].Evaluate(c, now)
+		assert.NoError(t, err)
+		assert.Empty(t, alerts)
+
+		alerts, err = rules[2].Evaluate(c, now)
+		assert.NoError(t, err)
+		if assert.Len(t, alerts, 1) {
+			assert.Equal(t, "vat-budget/2024-03", alerts[0].Key)
+			assert.Equal(t, 1000.0, alerts[0].Value)
+		}
+
+		alerts, err = rules[3].Evaluate(c, now)
+		assert.NoError(t, err)
+		if assert.Len(t, alerts, 1) {
+			assert.Equal(t, 48.0, alerts[0].Value)
+			assert.Equal(t, 24.0, alerts[0].Threshold)
+		}
+	})
+
+	t.Run("rejects invalid rules", func(t *testing.T) {
+		_, err := Rule{Type: "unknown"}.Evaluate(c, now)
+		assert.Error(t, err)
+
+		_, err = Rule{Type: RuleStale, SeriesID: 1}.Evaluate(c, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("notifies once and persists state", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state.json")
+		state, err := LoadAlertState(path)
+		assert.NoError(t, err)
+
+		notifier := &recordingNotifier{}
+		w := &Watcher{Client: c, Rules: rules, Notifiers: []Notifier{notifier}, State: state}
+
+		sent, err := w.Check(now)
+		assert.NoError(t, err)
+		assert.Len(t, sent, 3)
+		assert.Len(t, notifier.alerts, 3)
+
+		reloaded, err := LoadAlertState(path)
+		assert.NoError(t, err)
+		assert.True(t, reloaded.Seen("vat-budget/2024-03"))
+
+		w = &Watcher{Client: c, Rules: rules, Notifiers: []Notifier{notifier}, State: reloaded}
+		sent, err = w.Check(now.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, sent)
+		assert.Len(t, notifier.alerts, 3)
+	})
+
+	t.Run("retries alerts until every notifier accepts them", func(t *testing.T) {
+		failing := &recordingNotifier{err: errors.New("unreachable")}
+		working := &recordingNotifier{}
+		w := &Watcher{Client: c, Rules: rules[:1], Notifiers: []Notifier{failing, working}}
+
+		sent, err := w.Check(now)
+		assert.ErrorContains(t, err, "unreachable")
+		assert.Empty(t, sent)
+		assert.Len(t, working.alerts, 1)
+		assert.False(t, w.State.Seen("consumption-1/2024-03-15T11:00:00Z"))
+
+		failing.err = nil
+		sent, err = w.Check(now)
+		assert.NoError(t, err)
+		assert.Len(t, sent, 1)
+		assert.Len(t, failing.alerts, 1)
+		assert.True(t, w.State.Seen("consumption-1/2024-03-15T11:00:00Z"))
+	})
+}
+
+func TestAlertState(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	state, err := LoadAlertState(filepath.Join(t.TempDir(), "missing.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, state.Alerts)
+
+	state.Mark("old", now.Add(-100*24*time.Hour))
+	state.Mark("new", now)
+	state.Prune(now.Add(-AlertRetention))
+
+	assert.False(t, state.Seen("old"))
+	assert.True(t, state.Seen("new"))
+}
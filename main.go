@@ -1,6 +1,10 @@
 package main
 
-import "github.com/slimcdk/go-eon/cmd"
+import (
+	_ "time/tzdata" // Embed time zone database for --timezone on all platforms
+
+	"github.com/slimcdk/go-eon/cmd"
+)
 
 func main() {
 	cmd.Execute()